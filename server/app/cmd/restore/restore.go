@@ -0,0 +1,70 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+	"uptimatic/internal/adapters/minio"
+	"uptimatic/internal/archive"
+	"uptimatic/internal/config"
+	"uptimatic/internal/db"
+	"uptimatic/internal/url"
+	"uptimatic/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+const usage = "Usage: nama_app restore <monitor-id> <from YYYY-MM> [to YYYY-MM]"
+
+// Start loads a monitor's archived status logs for a range of months into status_logs_restored.
+// The range is inclusive and defaults to the single from month.
+func Start(args []string) {
+	ctx := context.Background()
+	ctx = utils.WithTraceID(ctx)
+
+	if len(args) < 2 || len(args) > 3 {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+
+	urlID, err := uuid.Parse(args[0])
+	if err != nil {
+		fmt.Println("Invalid monitor id:", args[0])
+		os.Exit(1)
+	}
+	from, err := time.Parse("2006-01", args[1])
+	if err != nil {
+		fmt.Println("Invalid from month:", args[1])
+		os.Exit(1)
+	}
+	to := from
+	if len(args) == 3 {
+		to, err = time.Parse("2006-01", args[2])
+		if err != nil || to.Before(from) {
+			fmt.Println("Invalid to month:", args[2])
+			os.Exit(1)
+		}
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		utils.Fatal(ctx, "Failed to load config", map[string]any{"error": err})
+	}
+
+	utils.InitLogger(&cfg, "uptimatic-restore")
+
+	psql := db.NewPostgresClient(&cfg)
+	minio, err := minio.NewMinioUtil(ctx, &cfg)
+	if err != nil {
+		utils.Fatal(ctx, "Failed to connect minio", map[string]any{"error": err})
+	}
+
+	archiveService := archive.NewArchiveService(psql, minio, url.NewLogRepository(), archive.NewArchiveRepository())
+	restored, err := archiveService.Restore(ctx, urlID, from, to.AddDate(0, 1, 0))
+	if err != nil {
+		utils.Fatal(ctx, "Failed to restore status logs", map[string]any{"url_id": urlID, "error": err.Error()})
+	}
+
+	utils.Info(ctx, "Status logs restored to status_logs_restored", map[string]any{"url_id": urlID, "from": args[1], "rows": restored})
+}
@@ -2,10 +2,16 @@ package scheduler
 
 import (
 	"context"
+	"net/http"
+	"os/signal"
+	"syscall"
 	"time"
 	"uptimatic/internal/config"
 	"uptimatic/internal/db"
+	"uptimatic/internal/health"
+	"uptimatic/internal/metrics"
 	"uptimatic/internal/tasks"
+	"uptimatic/internal/tracing"
 	"uptimatic/internal/utils"
 
 	"github.com/getsentry/sentry-go"
@@ -13,7 +19,8 @@ import (
 )
 
 func Start() {
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 	ctx = utils.WithTraceID(ctx)
 
 	cfg, err := config.LoadConfig()
@@ -21,11 +28,25 @@ func Start() {
 		panic(err)
 	}
 
-	utils.InitLogger(cfg.AppLogLevel)
+	utils.InitLogger(&cfg, "uptimatic-scheduler")
 	_ = utils.InitSentry(cfg.SentryDSN)
 	defer sentry.Flush(2 * time.Second)
 
-	scheduler := db.NewAsynqScheduler(&cfg)
+	shutdownTracing, err := tracing.Init(ctx, &cfg, "uptimatic-scheduler")
+	if err != nil {
+		utils.Fatal(ctx, "Failed to init tracing", map[string]any{"error": err})
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			utils.Error(context.Background(), "Failed to flush traces", map[string]any{"error": err.Error()})
+		}
+	}()
+
+	scheduler := db.NewAsynqScheduler(&cfg, func(info *asynq.TaskInfo, err error) {
+		if err == nil {
+			health.Beat(info.Type)
+		}
+	})
 
 	_, err = scheduler.Register(
 		"* * * * *",
@@ -36,8 +57,78 @@ func Start() {
 		return
 	}
 
-	utils.Debug(ctx, "Scheduler started", nil)
-	if err := scheduler.Run(); err != nil {
+	_, err = scheduler.Register(
+		"*/10 * * * *",
+		asynq.NewTask(tasks.TaskRollupStats, nil),
+		asynq.Unique(10*time.Minute),
+	)
+	if err != nil {
+		utils.Fatal(ctx, "Failed to register task", map[string]any{"error": err})
+		return
+	}
+
+	_, err = scheduler.Register(
+		"0 3 * * *",
+		asynq.NewTask(tasks.TaskPartitionLogs, nil),
+		asynq.Unique(time.Hour),
+		asynq.Timeout(2*time.Hour),
+	)
+	if err != nil {
+		utils.Fatal(ctx, "Failed to register task", map[string]any{"error": err})
+		return
+	}
+
+	_, err = scheduler.Register(
+		"30 3 * * *",
+		asynq.NewTask(tasks.TaskPruneRollups, nil),
+		asynq.Unique(time.Hour),
+	)
+	if err != nil {
+		utils.Fatal(ctx, "Failed to register task", map[string]any{"error": err})
+		return
+	}
+
+	_, err = scheduler.Register(
+		"*/15 * * * *",
+		asynq.NewTask(tasks.TaskCheckSLA, nil),
+		asynq.Unique(15*time.Minute),
+	)
+	if err != nil {
+		utils.Fatal(ctx, "Failed to register task", map[string]any{"error": err})
+		return
+	}
+
+	_, err = scheduler.Register(
+		"0 * * * *",
+		asynq.NewTask(tasks.TaskScheduleReports, nil),
+		asynq.Unique(time.Hour),
+	)
+	if err != nil {
+		utils.Fatal(ctx, "Failed to register task", map[string]any{"error": err})
+		return
+	}
+
+	inspector := asynq.NewInspector(db.RedisClientOpt(&cfg))
+	defer db.Close("asynq inspector", inspector)
+	if err := metrics.RegisterQueueCollector(inspector); err != nil {
+		utils.Fatal(ctx, "Failed to register queue metrics", map[string]any{"error": err})
+		return
+	}
+	metrics.Serve(ctx, cfg.MetricsSchedulerPort, map[string]http.Handler{
+		"/healthz": health.HeartbeatHandler(cfg.HealthHeartbeatMaxAge, tasks.TaskValidateUptime),
+		"/readyz": health.Readiness(map[string]health.Check{
+			"asynq": func(ctx context.Context) error { return scheduler.Ping() },
+		}),
+	})
+
+	if err := scheduler.Start(); err != nil {
 		utils.Fatal(ctx, "Failed to run scheduler", map[string]any{"error": err})
 	}
+	utils.Debug(ctx, "Scheduler started", nil)
+
+	<-ctx.Done()
+	stop()
+	utils.Info(ctx, "Shutting down scheduler", nil)
+	scheduler.Shutdown()
+	utils.Info(ctx, "Scheduler stopped", nil)
 }
@@ -2,14 +2,26 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
 	"time"
 	"uptimatic/internal/adapters/google"
 	"uptimatic/internal/adapters/minio"
+	"uptimatic/internal/archive"
 	"uptimatic/internal/auth"
 	"uptimatic/internal/config"
 	"uptimatic/internal/db"
+	"uptimatic/internal/export"
+	"uptimatic/internal/exporter"
+	"uptimatic/internal/health"
+	"uptimatic/internal/metrics"
 	"uptimatic/internal/middleware"
+	"uptimatic/internal/report"
+	"uptimatic/internal/statuspage"
+	"uptimatic/internal/tracing"
 	"uptimatic/internal/url"
 	"uptimatic/internal/user"
 	"uptimatic/internal/utils"
@@ -17,21 +29,38 @@ import (
 	"github.com/getsentry/sentry-go"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func Start() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		panic(err)
 	}
 
-	utils.InitLogger(cfg.AppLogLevel)
+	utils.InitLogger(&cfg, "uptimatic-server")
 	_ = utils.InitSentry(cfg.SentryDSN)
 	defer sentry.Flush(2 * time.Second)
 
+	shutdownTracing, err := tracing.Init(context.Background(), &cfg, "uptimatic-server")
+	if err != nil {
+		utils.Fatal(context.Background(), "Failed to init tracing", map[string]any{"error": err})
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			utils.Error(context.Background(), "Failed to flush traces", map[string]any{"error": err.Error()})
+		}
+	}()
+
 	pgsql := db.NewPostgresClient(&cfg)
+	defer db.ClosePostgres(pgsql)
 	redis := db.NewRedisClient(&cfg)
+	defer db.Close("redis", redis)
 	asyncClient := db.NewAsynqClient(&cfg)
+	defer db.Close("asynq", asyncClient)
 
 	jwtUtil := utils.NewJWTUtil(cfg.AuthJWTSecret, cfg.AuthAccessTokenExpiration, cfg.AuthRefreshTokenExpiration)
 	googleClient := google.NewGoogleClient(&cfg)
@@ -45,13 +74,36 @@ func Start() {
 	userRepo := user.NewUserRepository()
 	urlRepo := url.NewUrlRepository()
 	logRepo := url.NewLogRepository()
+	maintenanceRepo := url.NewMaintenanceRepository()
+	incidentRepo := url.NewIncidentRepository()
+	statusPageRepo := statuspage.NewStatusPageRepository()
+	statusIncidentRepo := statuspage.NewStatusIncidentRepository()
+	subscriberRepo := statuspage.NewSubscriberRepository()
+	metricsCache := url.NewMonitorMetricsCache(redis)
 
 	authService := auth.NewAuthService(pgsql, userRepo, redis, jwtUtil, asyncClient, googleClient)
-	urlService := url.NewUrlService(pgsql, urlRepo, logRepo)
+	archiveService := archive.NewArchiveService(pgsql, minio, logRepo, archive.NewArchiveRepository())
+	urlService := url.NewUrlService(pgsql, redis, urlRepo, logRepo, maintenanceRepo, archiveService)
+	badgeService := url.NewBadgeService(pgsql, redis, urlRepo, logRepo, maintenanceRepo)
+	maintenanceService := url.NewMaintenanceService(pgsql, urlRepo, maintenanceRepo)
+	statusPageService := statuspage.NewStatusPageService(pgsql, statusPageRepo, urlRepo, logRepo, maintenanceRepo, incidentRepo, statusIncidentRepo, minio)
+	statusIncidentService := statuspage.NewStatusIncidentService(pgsql, statusPageRepo, statusIncidentRepo, subscriberRepo, urlRepo, asyncClient)
+	subscriberService := statuspage.NewSubscriberService(pgsql, statusPageRepo, subscriberRepo, asyncClient)
+	exportService := export.NewExportService(pgsql, asyncClient, minio, urlRepo, logRepo, incidentRepo, userRepo)
+	reportService := report.NewReportService(pgsql, asyncClient, urlRepo, logRepo, incidentRepo, userRepo)
+	exporterService := exporter.NewExporterService(pgsql, userRepo, urlRepo, metricsCache)
 	userService := user.NewUserService(pgsql, userRepo, minio, redis, jwtUtil, asyncClient)
 
 	authHandler := auth.NewAuthHandler(authService, validate, &cfg)
 	urlHandler := url.NewURLHandler(urlService, validate)
+	maintenanceHandler := url.NewMaintenanceHandler(maintenanceService, validate)
+	badgeHandler := url.NewBadgeHandler(badgeService)
+	statusPageHandler := statuspage.NewStatusPageHandler(statusPageService, validate, &cfg)
+	statusIncidentHandler := statuspage.NewStatusIncidentHandler(statusIncidentService, validate, &cfg)
+	subscriberHandler := statuspage.NewSubscriberHandler(subscriberService, validate, &cfg)
+	exportHandler := export.NewExportHandler(exportService)
+	reportHandler := report.NewReportHandler(reportService)
+	exporterHandler := exporter.NewExporterHandler(exporterService)
 	userHandler := user.NewUserHandler(userService, validate, &cfg)
 
 	if cfg.AppDebug {
@@ -62,17 +114,58 @@ func Start() {
 
 	r := gin.New()
 	r.Use(gin.Recovery())
+	r.Use(otelgin.Middleware("uptimatic-server", otelgin.WithGinFilter(func(c *gin.Context) bool {
+		switch c.FullPath() {
+		case "/metrics", "/healthz", "/readyz":
+			return false
+		}
+		return true
+	})))
 	r.Use(middleware.RequestID())
+	r.Use(middleware.Metrics())
+	r.Use(middleware.AccessLog(cfg.AccessLogSampleRate, cfg.AccessLogSlowThreshold))
+
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+	r.GET("/healthz", gin.WrapH(health.Liveness()))
+	r.GET("/readyz", gin.WrapH(health.Readiness(map[string]health.Check{
+		"postgres": health.Postgres(pgsql),
+		"redis":    health.Redis(redis),
+		"asynq":    health.Asynq(asyncClient),
+		"minio":    minio.Ping,
+	})))
 
 	api := r.Group("/api/v1")
 	{
 		auth.AuthRoutes(api, authHandler, &jwtUtil)
 		user.UserRoutes(api, userHandler, &jwtUtil)
-		url.UrlRoutes(api, urlHandler, &jwtUtil)
+		url.UrlRoutes(api, urlHandler, maintenanceHandler, badgeHandler, &jwtUtil)
+		export.ExportRoutes(api, exportHandler, &jwtUtil)
+		report.ReportRoutes(api, reportHandler, &jwtUtil)
+		exporter.ExporterRoutes(api, exporterHandler)
+		statuspage.StatusPageRoutes(api, statusPageHandler, statusIncidentHandler, subscriberHandler, &jwtUtil)
+	}
+
+	srv := &http.Server{
+		Addr:              ":" + fmt.Sprint(cfg.AppPort),
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
 	}
+	go func() {
+		utils.Info(ctx, "Server started", map[string]any{"addr": srv.Addr})
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			utils.Fatal(ctx, "Failed to start server", map[string]any{"error": err})
+		}
+	}()
+
+	<-ctx.Done()
+	// A second signal now kills the process instead of waiting for the drain.
+	stop()
+	utils.Info(context.Background(), "Shutting down server", map[string]any{"timeout": cfg.ShutdownTimeout.String()})
 
-	addr := ":" + fmt.Sprint(cfg.AppPort)
-	if err := r.Run(addr); err != nil {
-		utils.Fatal(context.Background(), "Failed to start server", map[string]any{"error": err})
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		utils.Error(context.Background(), "Server did not drain in time", map[string]any{"error": err.Error()})
 	}
+	utils.Info(context.Background(), "Server stopped", nil)
 }
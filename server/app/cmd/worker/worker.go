@@ -2,12 +2,23 @@ package worker
 
 import (
 	"context"
+	"net/http"
+	"os/signal"
+	"syscall"
 	"time"
 	"uptimatic/internal/adapters/email"
+	"uptimatic/internal/adapters/minio"
+	"uptimatic/internal/archive"
 	"uptimatic/internal/config"
 	"uptimatic/internal/db"
+	"uptimatic/internal/export"
+	"uptimatic/internal/health"
+	"uptimatic/internal/metrics"
+	"uptimatic/internal/report"
 	"uptimatic/internal/tasks"
+	"uptimatic/internal/tracing"
 	"uptimatic/internal/url"
+	"uptimatic/internal/user"
 	"uptimatic/internal/utils"
 
 	"github.com/getsentry/sentry-go"
@@ -15,7 +26,8 @@ import (
 )
 
 func Start() {
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 	ctx = utils.WithTraceID(ctx)
 
 	cfg, err := config.LoadConfig()
@@ -23,32 +35,101 @@ func Start() {
 		utils.Fatal(ctx, "Failed to load config", map[string]any{"error": err})
 	}
 
-	utils.InitLogger(cfg.AppLogLevel)
+	utils.InitLogger(&cfg, "uptimatic-worker")
 	_ = utils.InitSentry(cfg.SentryDSN)
 	defer sentry.Flush(2 * time.Second)
 
+	shutdownTracing, err := tracing.Init(ctx, &cfg, "uptimatic-worker")
+	if err != nil {
+		utils.Fatal(ctx, "Failed to init tracing", map[string]any{"error": err})
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			utils.Error(context.Background(), "Failed to flush traces", map[string]any{"error": err.Error()})
+		}
+	}()
+
 	psql := db.NewPostgresClient(&cfg)
+	defer db.ClosePostgres(psql)
 	client := db.NewAsynqClient(&cfg)
+	defer db.Close("asynq", client)
+	redis := db.NewRedisClient(&cfg)
+	defer db.Close("redis", redis)
+
+	minio, err := minio.NewMinioUtil(ctx, &cfg)
+	if err != nil {
+		utils.Fatal(ctx, "Failed to connect minio", map[string]any{"error": err})
+	}
 
+	userRepo := user.NewUserRepository()
 	urlRepo := url.NewUrlRepository()
 	logRepo := url.NewLogRepository()
+	maintenanceRepo := url.NewMaintenanceRepository()
+	incidentRepo := url.NewIncidentRepository()
+	rollupRepo := url.NewRollupRepository()
+	partitionRepo := url.NewStatusLogPartitionRepository()
+	slaAlertRepo := url.NewSLAAlertRepository()
+	archiveRepo := archive.NewArchiveRepository()
+	metricsCache := url.NewMonitorMetricsCache(redis)
 
 	mailTask, err := email.NewEmailTask(&cfg)
 	if err != nil {
 		utils.Fatal(ctx, "Failed to create email task", map[string]any{"error": err})
 	}
 
-	handler := tasks.NewTaskHandler(&cfg, psql, client, mailTask, urlRepo, logRepo)
+	archiveService := archive.NewArchiveService(psql, minio, logRepo, archiveRepo)
+	handler := tasks.NewTaskHandler(&cfg, psql, client, mailTask, urlRepo, logRepo, maintenanceRepo, incidentRepo, rollupRepo, partitionRepo, slaAlertRepo, archiveService, metricsCache)
+	exportService := export.NewExportService(psql, client, minio, urlRepo, logRepo, incidentRepo, userRepo)
+	// Not every storage backend supports lifecycle rules, so exports just pile up without one.
+	if err := exportService.ExpireObjects(ctx); err != nil {
+		utils.Warn(ctx, "Failed to set export expiry, old exports will not be deleted", map[string]any{"error": err.Error()})
+	}
+	exportHandler := export.NewTaskHandler(&cfg, exportService)
+	reportService := report.NewReportService(psql, client, urlRepo, logRepo, incidentRepo, userRepo)
+	reportHandler := report.NewTaskHandler(&cfg, reportService)
 
-	srv := db.NewAsynqServer(&cfg)
+	srv := db.NewAsynqServer(&cfg, func(err error) {
+		if err != nil {
+			utils.Warn(ctx, "Asynq health check failed", map[string]any{"error": err.Error()})
+			return
+		}
+		health.Beat(tasks.WorkerHeartbeat)
+	})
 	mux := asynq.NewServeMux()
 
 	mux.HandleFunc(tasks.TaskSendEmail, tasks.MiddlewareHandler(handler.SendEmailHandler))
 	mux.HandleFunc(tasks.TaskValidateUptime, tasks.MiddlewareHandler(handler.ValidateUptimeHandler))
 	mux.HandleFunc(tasks.TaskCheckUptime, tasks.MiddlewareHandler(handler.CheckUptimeHandler))
+	mux.HandleFunc(tasks.TaskRollupStats, tasks.MiddlewareHandler(handler.RollupStatsHandler))
+	mux.HandleFunc(tasks.TaskPartitionLogs, tasks.MiddlewareHandler(handler.PartitionStatusLogsHandler))
+	mux.HandleFunc(tasks.TaskPruneRollups, tasks.MiddlewareHandler(handler.PruneRollupsHandler))
+	mux.HandleFunc(tasks.TaskCheckSLA, tasks.MiddlewareHandler(handler.CheckSLAHandler))
+	mux.HandleFunc(tasks.TaskExport, tasks.MiddlewareHandler(exportHandler.ExportHandler))
+	mux.HandleFunc(tasks.TaskScheduleReports, tasks.MiddlewareHandler(reportHandler.ScheduleReportsHandler))
+	mux.HandleFunc(tasks.TaskSendReport, tasks.MiddlewareHandler(reportHandler.SendReportHandler))
 
-	utils.Debug(ctx, "Worker started", nil)
-	if err := srv.Run(mux); err != nil {
+	// Only one worker dequeues each validate_uptime, so liveness follows the beat of this process
+	// instead of any single task type.
+	metrics.Serve(ctx, cfg.MetricsWorkerPort, map[string]http.Handler{
+		"/healthz": health.HeartbeatHandler(cfg.HealthHeartbeatMaxAge, tasks.WorkerHeartbeat),
+		"/readyz": health.Readiness(map[string]health.Check{
+			"postgres": health.Postgres(psql),
+			"redis":    health.Redis(redis),
+			"asynq":    health.Asynq(client),
+			"minio":    minio.Ping,
+		}),
+	})
+
+	if err := srv.Start(mux); err != nil {
 		utils.Fatal(ctx, "Failed to start server", map[string]any{"error": err})
 	}
+	utils.Debug(ctx, "Worker started", nil)
+
+	<-ctx.Done()
+	stop()
+	utils.Info(ctx, "Shutting down worker", map[string]any{"timeout": cfg.ShutdownTimeout.String()})
+	// Shutdown stops pulling new tasks and waits for in-flight ones, requeueing whatever is still
+	// running after the timeout.
+	srv.Shutdown()
+	utils.Info(ctx, "Worker stopped", nil)
 }
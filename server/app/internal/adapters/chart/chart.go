@@ -0,0 +1,180 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+)
+
+// ContentType is the MIME type of the rendered charts.
+const ContentType = "image/png"
+
+const (
+	width   = 560
+	height  = 120
+	padding = 6
+	barGap  = 2
+	// gridLines is how many horizontal guides split the plot area.
+	gridLines = 4
+)
+
+var (
+	background    = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	gridColor     = color.RGBA{0xf1, 0xf5, 0xf9, 0xff}
+	lineColor     = color.RGBA{0x25, 0x63, 0xeb, 0xff}
+	areaColor     = color.RGBA{0xdb, 0xea, 0xfe, 0xff}
+	upColor       = color.RGBA{0x16, 0xa3, 0x4a, 0xff}
+	degradedColor = color.RGBA{0xd9, 0x77, 0x06, 0xff}
+	downColor     = color.RGBA{0xdc, 0x26, 0x26, 0xff}
+	emptyColor    = color.RGBA{0xe5, 0xe7, 0xeb, 0xff}
+)
+
+// ResponseTime draws values as a filled line chart scaled to the largest value.
+// Nil values are buckets without checks and leave a gap in the line.
+func ResponseTime(values []*float64) ([]byte, error) {
+	img := newCanvas()
+	plot := plotArea()
+
+	peak := 0.0
+	for _, v := range values {
+		if v != nil {
+			peak = math.Max(peak, *v)
+		}
+	}
+	if peak == 0 {
+		peak = 1
+	}
+
+	x := func(i int) int {
+		if len(values) < 2 {
+			return plot.Min.X + plot.Dx()/2
+		}
+		return plot.Min.X + i*(plot.Dx()-1)/(len(values)-1)
+	}
+	y := func(v float64) int {
+		return plot.Max.Y - 1 - int(math.Round(v/peak*float64(plot.Dy()-1)))
+	}
+
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		if i+1 >= len(values) || values[i+1] == nil {
+			if i == 0 || values[i-1] == nil {
+				fillRect(img, image.Rect(x(i)-1, y(*v), x(i)+2, plot.Max.Y), areaColor)
+				fillRect(img, image.Rect(x(i)-2, y(*v)-2, x(i)+2, y(*v)+2), lineColor)
+			}
+			continue
+		}
+
+		x0, y0, x1, y1 := x(i), y(*v), x(i+1), y(*values[i+1])
+		for px := x0; px <= x1; px++ {
+			py := y0
+			if x1 > x0 {
+				py = y0 + (y1-y0)*(px-x0)/(x1-x0)
+			}
+			fillRect(img, image.Rect(px, py, px+1, plot.Max.Y), areaColor)
+		}
+		drawLine(img, x0, y0, x1, y1, lineColor)
+	}
+
+	return encode(img)
+}
+
+// UptimeBars draws one full-height bar per bucket coloured by its uptime percentage, like the
+// status page. Nil values are buckets without checks and are drawn grey.
+func UptimeBars(values []*float64) ([]byte, error) {
+	img := newCanvas()
+	plot := plotArea()
+	if len(values) == 0 {
+		return encode(img)
+	}
+
+	step := float64(plot.Dx()+barGap) / float64(len(values))
+	for i, v := range values {
+		x0 := plot.Min.X + int(math.Round(float64(i)*step))
+		x1 := plot.Min.X + int(math.Round(float64(i+1)*step)) - barGap
+		fillRect(img, image.Rect(x0, plot.Min.Y, max(x1, x0+1), plot.Max.Y), uptimeColor(v))
+	}
+
+	return encode(img)
+}
+
+func uptimeColor(v *float64) color.RGBA {
+	switch {
+	case v == nil:
+		return emptyColor
+	case *v >= 99:
+		return upColor
+	case *v >= 95:
+		return degradedColor
+	default:
+		return downColor
+	}
+}
+
+func newCanvas() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{background}, image.Point{}, draw.Src)
+
+	plot := plotArea()
+	for i := 0; i <= gridLines; i++ {
+		y := plot.Min.Y + i*(plot.Dy()-1)/gridLines
+		fillRect(img, image.Rect(plot.Min.X, y, plot.Max.X, y+1), gridColor)
+	}
+	return img
+}
+
+func plotArea() image.Rectangle {
+	return image.Rect(padding, padding, width-padding, height-padding)
+}
+
+func fillRect(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	draw.Draw(img, r.Intersect(img.Bounds()), &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// drawLine draws a two pixel wide line with Bresenham's algorithm.
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+
+	for e := dx + dy; ; {
+		fillRect(img, image.Rect(x0-1, y0-1, x0+1, y0+1), c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
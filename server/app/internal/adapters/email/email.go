@@ -25,11 +25,16 @@ type EmailTask struct {
 type EmailType string
 
 const (
-	EmailWelcome       EmailType = "welcome"
-	EmailVerify        EmailType = "verify"
-	EmailPasswordReset EmailType = "password_reset"
-	EmailDown          EmailType = "down"
-	EmailUp            EmailType = "up"
+	EmailWelcome         EmailType = "welcome"
+	EmailVerify          EmailType = "verify"
+	EmailPasswordReset   EmailType = "password_reset"
+	EmailDown            EmailType = "down"
+	EmailUp              EmailType = "up"
+	EmailStatusSubscribe EmailType = "status_subscribe"
+	EmailStatusUpdate    EmailType = "status_update"
+	EmailExportReady     EmailType = "export_ready"
+	EmailSLABudget       EmailType = "sla_budget"
+	EmailUptimeReport    EmailType = "uptime_report"
 )
 
 type EmailPayload struct {
@@ -37,6 +42,15 @@ type EmailPayload struct {
 	Subject string         `json:"subject"`
 	Type    EmailType      `json:"type"`
 	Data    map[string]any `json:"data"`
+	Images  []InlineImage  `json:"images,omitempty"`
+}
+
+// InlineImage is attached to the message as a related part, so templates can show it with
+// a fixed <img src="cid:..."> where CID matches Name.
+type InlineImage struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"`
 }
 
 func NewEmailTask(cfg *config.Config) (*EmailTask, error) {
@@ -45,7 +59,7 @@ func NewEmailTask(cfg *config.Config) (*EmailTask, error) {
 		tplCache: map[EmailType]*template.Template{},
 	}
 
-	types := []EmailType{EmailWelcome, EmailVerify, EmailPasswordReset, EmailDown, EmailUp}
+	types := []EmailType{EmailWelcome, EmailVerify, EmailPasswordReset, EmailDown, EmailUp, EmailStatusSubscribe, EmailStatusUpdate, EmailExportReady, EmailSLABudget, EmailUptimeReport}
 	for _, typ := range types {
 		tpl, err := template.ParseFS(templatesFS, fmt.Sprintf("templates/%s.html", typ))
 		if err != nil {
@@ -57,7 +71,7 @@ func NewEmailTask(cfg *config.Config) (*EmailTask, error) {
 	return t, nil
 }
 
-func (m *EmailTask) SendEmail(ctx context.Context, to, subject string, emailType EmailType, data map[string]any) error {
+func (m *EmailTask) SendEmail(ctx context.Context, to, subject string, emailType EmailType, data map[string]any, images ...InlineImage) error {
 	tpl, ok := m.tplCache[emailType]
 	if !ok {
 		return fmt.Errorf("template not found for type %s", emailType)
@@ -74,6 +88,13 @@ func (m *EmailTask) SendEmail(ctx context.Context, to, subject string, emailType
 	e.Subject = subject
 	e.Text = []byte("Please view this email in HTML format")
 	e.HTML = body.Bytes()
+	for _, img := range images {
+		attachment, err := e.Attach(bytes.NewReader(img.Data), img.Name, img.ContentType)
+		if err != nil {
+			return fmt.Errorf("failed to attach image %s: %w", img.Name, err)
+		}
+		attachment.HTMLRelated = true
+	}
 
 	addr := fmt.Sprintf("%s:%d", m.cfg.EmailSmtpHost, m.cfg.EmailSmtpPort)
 	auth := smtp.PlainAuth("", m.cfg.EmailSmtpUser, m.cfg.EmailSmtpPass, m.cfg.EmailSmtpHost)
@@ -3,6 +3,7 @@ package minio
 import (
 	"context"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/url"
 	"time"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
 type MinioUtil struct {
@@ -41,6 +43,45 @@ func NewMinioUtil(ctx context.Context, cfg *config.Config) (*MinioUtil, error) {
 	return &MinioUtil{Client: client, Bucket: cfg.StorageBucket, Cfg: cfg}, nil
 }
 
+// Ping checks that the storage endpoint answers and the bucket still exists.
+func (m *MinioUtil) Ping(ctx context.Context) error {
+	exists, err := m.Client.BucketExists(ctx, m.Bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", m.Bucket)
+	}
+	return nil
+}
+
+// ExpirePrefix sets a lifecycle rule, identified by id, that deletes objects under prefix once they
+// are days old. Other rules on the bucket are kept.
+func (m *MinioUtil) ExpirePrefix(ctx context.Context, id, prefix string, days int) error {
+	config, err := m.Client.GetBucketLifecycle(ctx, m.Bucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code != "NoSuchLifecycleConfiguration" {
+			return err
+		}
+		config = lifecycle.NewConfiguration()
+	}
+
+	rule := lifecycle.Rule{
+		ID:         id,
+		Status:     "Enabled",
+		RuleFilter: lifecycle.Filter{Prefix: prefix},
+		Expiration: lifecycle.Expiration{Days: lifecycle.ExpirationDays(days)},
+	}
+	rules := []lifecycle.Rule{rule}
+	for _, existing := range config.Rules {
+		if existing.ID != id {
+			rules = append(rules, existing)
+		}
+	}
+	config.Rules = rules
+	return m.Client.SetBucketLifecycle(ctx, m.Bucket, config)
+}
+
 func (m *MinioUtil) UploadFile(ctx context.Context, file multipart.File, fileName, contentType string, size int64) error {
 	_, err := m.Client.PutObject(ctx, m.Bucket, fileName, file, size, minio.PutObjectOptions{
 		ContentType: contentType,
@@ -51,6 +92,19 @@ func (m *MinioUtil) UploadFile(ctx context.Context, file multipart.File, fileNam
 	return nil
 }
 
+// UploadStream uploads a body of unknown length, letting the client split it into multipart chunks.
+func (m *MinioUtil) UploadStream(ctx context.Context, body io.Reader, fileName, contentType string) error {
+	_, err := m.Client.PutObject(ctx, m.Bucket, fileName, body, -1, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+// Download opens an object for reading; the caller must close it.
+func (m *MinioUtil) Download(ctx context.Context, fileName string) (io.ReadCloser, error) {
+	return m.Client.GetObject(ctx, m.Bucket, fileName, minio.GetObjectOptions{})
+}
+
 func (m *MinioUtil) DeleteFile(ctx context.Context, fileName string) error {
 	err := m.Client.RemoveObject(ctx, m.Bucket, fileName, minio.RemoveObjectOptions{})
 	if err != nil {
@@ -72,6 +126,17 @@ func (m *MinioUtil) GetPresignedURL(ctx context.Context, fileName string) (strin
 	return presignedURL.String(), nil
 }
 
+// GetPresignedDownloadURL signs a link that downloads the object as downloadName.
+func (m *MinioUtil) GetPresignedDownloadURL(ctx context.Context, fileName, downloadName string, expiry time.Duration) (string, error) {
+	reqParams := make(url.Values)
+	reqParams.Set("response-content-disposition", fmt.Sprintf("attachment; filename=%q", downloadName))
+	presignedURL, err := m.Client.PresignedGetObject(ctx, m.Bucket, fileName, expiry, reqParams)
+	if err != nil {
+		return "", err
+	}
+	return presignedURL.String(), nil
+}
+
 func (m *MinioUtil) PutPresignedURL(ctx context.Context, fileName, contentType string) (string, error) {
 	if contentType != "image/jpeg" &&
 		contentType != "image/png" &&
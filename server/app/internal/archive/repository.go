@@ -0,0 +1,98 @@
+package archive
+
+import (
+	"context"
+	"time"
+	"uptimatic/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ArchiveRepository interface {
+	ListURLsWithLogs(ctx context.Context, tx *gorm.DB, start, end time.Time) ([]models.URL, error)
+	ListMonthsWithLogs(ctx context.Context, tx *gorm.DB, urlID uint) ([]time.Time, error)
+	Exists(ctx context.Context, tx *gorm.DB, urlID uuid.UUID, month time.Time) (bool, error)
+	Upsert(ctx context.Context, tx *gorm.DB, archive *models.StatusLogArchive) error
+	ListByURLID(ctx context.Context, tx *gorm.DB, urlID uuid.UUID, start, end time.Time) ([]models.StatusLogArchive, error)
+	InsertRestored(ctx context.Context, tx *gorm.DB, logs []models.RestoredStatusLog) (int64, error)
+}
+
+type archiveRepository struct{}
+
+func NewArchiveRepository() ArchiveRepository {
+	return &archiveRepository{}
+}
+
+// ListURLsWithLogs returns the monitors that have at least one status log in [start, end).
+func (r *archiveRepository) ListURLsWithLogs(ctx context.Context, tx *gorm.DB, start, end time.Time) ([]models.URL, error) {
+	var urls []models.URL
+	err := tx.WithContext(ctx).
+		Where("id IN (SELECT DISTINCT url_id FROM status_logs WHERE checked_at >= ? AND checked_at < ?)", start, end).
+		Order("id ASC").
+		Find(&urls).Error
+	if err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+// ListMonthsWithLogs returns the UTC months a monitor still has status logs in, oldest first.
+func (r *archiveRepository) ListMonthsWithLogs(ctx context.Context, tx *gorm.DB, urlID uint) ([]time.Time, error) {
+	var months []time.Time
+	err := tx.WithContext(ctx).Raw(`
+		SELECT DISTINCT date_trunc('month', checked_at AT TIME ZONE 'UTC') AS month
+		FROM status_logs
+		WHERE url_id = ?
+		ORDER BY month;
+	`, urlID).Scan(&months).Error
+	if err != nil {
+		return nil, err
+	}
+	return months, nil
+}
+
+func (r *archiveRepository) Exists(ctx context.Context, tx *gorm.DB, urlID uuid.UUID, month time.Time) (bool, error) {
+	var count int64
+	err := tx.WithContext(ctx).
+		Model(&models.StatusLogArchive{}).
+		Where("url_public_id = ? AND month = ?", urlID, month).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *archiveRepository) Upsert(ctx context.Context, tx *gorm.DB, archive *models.StatusLogArchive) error {
+	return tx.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "url_public_id"}, {Name: "month"}},
+			DoUpdates: clause.AssignmentColumns([]string{"object_key", "row_count", "archived_at"}),
+		}).
+		Create(archive).Error
+}
+
+// ListByURLID returns the archived months of a monitor starting in [start, end), oldest first.
+func (r *archiveRepository) ListByURLID(ctx context.Context, tx *gorm.DB, urlID uuid.UUID, start, end time.Time) ([]models.StatusLogArchive, error) {
+	var archives []models.StatusLogArchive
+	err := tx.WithContext(ctx).
+		Where("url_public_id = ? AND month >= ? AND month < ?", urlID, start, end).
+		Order("month ASC").
+		Find(&archives).Error
+	if err != nil {
+		return nil, err
+	}
+	return archives, nil
+}
+
+// InsertRestored loads archived logs into status_logs_restored, skipping rows restored before,
+// and returns how many rows were added.
+func (r *archiveRepository) InsertRestored(ctx context.Context, tx *gorm.DB, logs []models.RestoredStatusLog) (int64, error) {
+	if len(logs) == 0 {
+		return 0, nil
+	}
+	result := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&logs)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,263 @@
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"uptimatic/internal/adapters/minio"
+	"uptimatic/internal/db"
+	"uptimatic/internal/export/format"
+	"uptimatic/internal/models"
+	"uptimatic/internal/url"
+	"uptimatic/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// restoreBatchSize is how many archived rows are inserted per statement when restoring.
+const restoreBatchSize = 1000
+
+// archiveColumns are the fields kept for every archived check; the names match the JSON tags of
+// models.RestoredStatusLog so archives can be decoded straight back into it.
+var archiveColumns = []format.Column{
+	{Name: "id", Type: format.ColumnInt64},
+	{Name: "checked_at", Type: format.ColumnTimestamp},
+	{Name: "status", Type: format.ColumnInt32},
+	{Name: "response_time", Type: format.ColumnInt64},
+	{Name: "in_maintenance", Type: format.ColumnBool},
+	{Name: "dependent", Type: format.ColumnBool},
+	{Name: "dns_time", Type: format.ColumnInt64, Optional: true},
+	{Name: "connect_time", Type: format.ColumnInt64, Optional: true},
+	{Name: "tls_time", Type: format.ColumnInt64, Optional: true},
+	{Name: "ttfb_time", Type: format.ColumnInt64, Optional: true},
+	{Name: "transfer_time", Type: format.ColumnInt64, Optional: true},
+	{Name: "remote_ip", Type: format.ColumnString, Optional: true},
+	{Name: "protocol", Type: format.ColumnString, Optional: true},
+	{Name: "response_size", Type: format.ColumnInt64, Optional: true},
+	{Name: "error_kind", Type: format.ColumnString, Optional: true},
+}
+
+// ObjectKey is where a monitor's logs for one UTC month are archived in the bucket. The bucket
+// serves unsigned public URLs, so the key ends in a random part nobody can derive from ids that
+// badges and status pages expose; the key is only known through status_log_archives.
+func ObjectKey(userID uint, urlID uuid.UUID, month time.Time) string {
+	return fmt.Sprintf("archives/status_logs/%d/%s/%s-%s.ndjson.gz", userID, urlID, month.UTC().Format("2006-01"), uuid.New())
+}
+
+type ArchiveService interface {
+	ArchivePartition(ctx context.Context, partition *url.StatusLogPartition) error
+	ArchiveURL(ctx context.Context, tx *gorm.DB, u *models.URL) error
+	Restore(ctx context.Context, urlID uuid.UUID, start, end time.Time) (int64, error)
+}
+
+type archiveService struct {
+	db          *gorm.DB
+	minio       *minio.MinioUtil
+	logRepo     url.StatusLogRepository
+	archiveRepo ArchiveRepository
+}
+
+func NewArchiveService(db *gorm.DB, minio *minio.MinioUtil, logRepo url.StatusLogRepository, archiveRepo ArchiveRepository) ArchiveService {
+	return &archiveService{db, minio, logRepo, archiveRepo}
+}
+
+// ArchivePartition writes every monitor's logs in a monthly partition to the bucket as gzipped NDJSON.
+// Months already archived are skipped, so a run interrupted halfway can simply be repeated.
+func (s *archiveService) ArchivePartition(ctx context.Context, partition *url.StatusLogPartition) error {
+	urls, err := s.archiveRepo.ListURLsWithLogs(ctx, s.db, partition.Start, partition.End)
+	if err != nil {
+		return fmt.Errorf("failed to list monitors to archive: %w", err)
+	}
+
+	for i := range urls {
+		u := &urls[i]
+		exists, err := s.archiveRepo.Exists(ctx, s.db, u.PublicID, partition.Start)
+		if err != nil {
+			return fmt.Errorf("failed to check archive: %w", err)
+		}
+		if exists {
+			continue
+		}
+
+		if err := s.archiveURL(ctx, s.db, u, partition); err != nil {
+			utils.Error(ctx, "Failed to archive status logs", map[string]any{"url_id": u.PublicID, "partition": partition.Name, "error": err.Error()})
+			return err
+		}
+	}
+
+	utils.Info(ctx, "Status log partition archived", map[string]any{"partition": partition.Name, "monitors": len(urls)})
+	return nil
+}
+
+// ArchiveURL writes every month a monitor still has logs in to the bucket. It runs before a monitor
+// is deleted, since its logs cascade away with it and would never reach a partition archive. The
+// archives are recorded through tx, which must also delete the monitor: if the delete rolls back,
+// no partial month is marked archived and the partition job still archives the whole month later.
+func (s *archiveService) ArchiveURL(ctx context.Context, tx *gorm.DB, u *models.URL) error {
+	months, err := s.archiveRepo.ListMonthsWithLogs(ctx, s.db, u.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list months to archive: %w", err)
+	}
+
+	for _, month := range months {
+		partition := url.NewStatusLogPartition(month)
+		exists, err := s.archiveRepo.Exists(ctx, s.db, u.PublicID, partition.Start)
+		if err != nil {
+			return fmt.Errorf("failed to check archive: %w", err)
+		}
+		if exists {
+			continue
+		}
+
+		if err := s.archiveURL(ctx, tx, u, &partition); err != nil {
+			utils.Error(ctx, "Failed to archive status logs", map[string]any{"url_id": u.PublicID, "partition": partition.Name, "error": err.Error()})
+			return err
+		}
+	}
+
+	utils.Info(ctx, "Monitor status logs archived", map[string]any{"url_id": u.PublicID, "months": len(months)})
+	return nil
+}
+
+func (s *archiveService) archiveURL(ctx context.Context, tx *gorm.DB, u *models.URL, partition *url.StatusLogPartition) error {
+	objectKey := ObjectKey(u.UserID, u.PublicID, partition.Start)
+
+	var rows int64
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(s.write(ctx, u.ID, partition, writer, &rows))
+	}()
+	if err := s.minio.UploadStream(ctx, reader, objectKey, "application/gzip"); err != nil {
+		reader.CloseWithError(err)
+		return fmt.Errorf("failed to upload archive: %w", err)
+	}
+
+	err := s.archiveRepo.Upsert(ctx, tx, &models.StatusLogArchive{
+		UserID:      u.UserID,
+		URLID:       u.ID,
+		URLPublicID: u.PublicID,
+		Month:       partition.Start,
+		ObjectKey:   objectKey,
+		RowCount:    rows,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record archive: %w", err)
+	}
+
+	utils.Debug(ctx, "Status logs archived", map[string]any{"url_id": u.PublicID, "object": objectKey, "rows": rows})
+	return nil
+}
+
+func (s *archiveService) write(ctx context.Context, urlID uint, partition *url.StatusLogPartition, w io.Writer, rows *int64) error {
+	gz := gzip.NewWriter(w)
+	rw, err := format.NewWriter(format.NDJSON, gz, archiveColumns)
+	if err != nil {
+		return err
+	}
+
+	err = s.logRepo.StreamByURLID(ctx, s.db, urlID, partition.Start, partition.End, func(log *models.StatusLog) error {
+		status, _ := strconv.Atoi(log.Status)
+		*rows++
+		return rw.Write([]any{
+			int64(log.ID), log.CheckedAt, int32(status), log.ResponseTime, log.InMaintenance, log.Dependent,
+			optional(log.DNSTime), optional(log.ConnectTime), optional(log.TLSTime), optional(log.TTFBTime),
+			optional(log.TransferTime), optional(log.RemoteIP), optional(log.Protocol), optional(log.ResponseSize), optional(log.ErrorKind),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if err := rw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Restore loads a monitor's archived months starting in [start, end) into status_logs_restored
+// and returns how many rows were added. Restoring the same range twice adds nothing.
+func (s *archiveService) Restore(ctx context.Context, urlID uuid.UUID, start, end time.Time) (int64, error) {
+	archives, err := s.archiveRepo.ListByURLID(ctx, s.db, urlID, start, end)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list archives: %w", err)
+	}
+	if len(archives) == 0 {
+		return 0, fmt.Errorf("no archives found for %s between %s and %s", urlID, start.Format("2006-01"), end.Format("2006-01"))
+	}
+
+	var restored int64
+	for i := range archives {
+		n, err := s.restoreArchive(ctx, &archives[i])
+		if err != nil {
+			utils.Error(ctx, "Failed to restore archive", map[string]any{"object": archives[i].ObjectKey, "error": err.Error()})
+			return restored, err
+		}
+		restored += n
+		utils.Info(ctx, "Archive restored", map[string]any{"object": archives[i].ObjectKey, "rows": n})
+	}
+	return restored, nil
+}
+
+func (s *archiveService) restoreArchive(ctx context.Context, archive *models.StatusLogArchive) (int64, error) {
+	object, err := s.minio.Download(ctx, archive.ObjectKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download archive: %w", err)
+	}
+	defer object.Close()
+
+	gz, err := gzip.NewReader(object)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	var restored int64
+	err = db.WithTransaction(s.db, func(tx *gorm.DB) error {
+		decoder := json.NewDecoder(gz)
+		batch := make([]models.RestoredStatusLog, 0, restoreBatchSize)
+		for {
+			var log models.RestoredStatusLog
+			err := decoder.Decode(&log)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to decode archive: %w", err)
+			}
+
+			log.URLID = archive.URLID
+			batch = append(batch, log)
+			if len(batch) < restoreBatchSize {
+				continue
+			}
+
+			n, err := s.archiveRepo.InsertRestored(ctx, tx, batch)
+			if err != nil {
+				return err
+			}
+			restored += n
+			batch = batch[:0]
+		}
+
+		n, err := s.archiveRepo.InsertRestored(ctx, tx, batch)
+		restored += n
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return restored, nil
+}
+
+// optional unwraps a nullable column for the format writer, which expects a bare nil for no value.
+func optional[T any](value *T) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
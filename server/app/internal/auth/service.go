@@ -73,7 +73,7 @@ func (s *authService) Register(ctx context.Context, name, userEmail, password, a
 
 	link := fmt.Sprintf("%s/auth/verify?token=%s", appUrl, token)
 	iconUrl := fmt.Sprintf("%s/icon.png", appUrl)
-	if err := tasks.EnqueueEmail(s.asyncClient, userEmail, "Verify your email - Uptimatic", email.EmailVerify, map[string]any{
+	if err := tasks.EnqueueEmail(ctx, s.asyncClient, userEmail, "Verify your email - Uptimatic", email.EmailVerify, map[string]any{
 		"LogoURL":          iconUrl,
 		"Name":             userEmail,
 		"VerificationLink": link,
@@ -233,7 +233,7 @@ func (s *authService) ResendVerificationEmail(ctx context.Context, userID uint,
 
 	link := fmt.Sprintf("%s/auth/verify?token=%s", appUrl, token)
 	iconUrl := fmt.Sprintf("%s/icon.png", appUrl)
-	if err := tasks.EnqueueEmail(s.asyncClient, user.Email, "Verify your email - Uptimatic", email.EmailVerify, map[string]any{
+	if err := tasks.EnqueueEmail(ctx, s.asyncClient, user.Email, "Verify your email - Uptimatic", email.EmailVerify, map[string]any{
 		"LogoURL":          iconUrl,
 		"Name":             user.Email,
 		"VerificationLink": link,
@@ -296,7 +296,7 @@ func (s *authService) SendPasswordResetEmail(ctx context.Context, userEmail, app
 
 	link := fmt.Sprintf("%s/auth/reset-password?token=%s", appUrl, token)
 	iconUrl := fmt.Sprintf("%s/icon.png", appUrl)
-	if err := tasks.EnqueueEmail(s.asyncClient, user.Email, "Reset your password - Uptimatic", email.EmailPasswordReset, map[string]any{
+	if err := tasks.EnqueueEmail(ctx, s.asyncClient, user.Email, "Reset your password - Uptimatic", email.EmailPasswordReset, map[string]any{
 		"LogoURL":   iconUrl,
 		"Name":      user.Email,
 		"ResetLink": link,
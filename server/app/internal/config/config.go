@@ -14,6 +14,15 @@ type Config struct {
 	AppDomain   string
 	AppScheme   string
 
+	AppLogFormat         string
+	AppLogFile           string
+	AppLogFileMaxSize    int
+	AppLogFileMaxBackups int
+	AppLogFileMaxAge     int
+
+	AccessLogSampleRate    float64
+	AccessLogSlowThreshold time.Duration
+
 	AuthJWTSecret              string
 	AuthAccessTokenExpiration  time.Duration
 	AuthRefreshTokenExpiration time.Duration
@@ -45,6 +54,19 @@ type Config struct {
 	StorageUseSSL    bool
 
 	SentryDSN string
+
+	RetentionStatusLogDays    int
+	RetentionHourlyRollupDays int
+
+	MetricsWorkerPort    int
+	MetricsSchedulerPort int
+
+	TracingOTLPEndpoint string
+	TracingSampleRatio  float64
+
+	HealthHeartbeatMaxAge time.Duration
+
+	ShutdownTimeout time.Duration
 }
 
 func LoadConfig() (Config, error) {
@@ -67,6 +89,15 @@ func LoadConfig() (Config, error) {
 		AppDomain:   viper.GetString("APP_DOMAIN"),
 		AppScheme:   viper.GetString("APP_SCHEME"),
 
+		AppLogFormat:         viper.GetString("APP_LOG_FORMAT"),
+		AppLogFile:           viper.GetString("APP_LOG_FILE"),
+		AppLogFileMaxSize:    getIntOrDefault("APP_LOG_FILE_MAX_SIZE", 100),
+		AppLogFileMaxBackups: getIntOrDefault("APP_LOG_FILE_MAX_BACKUPS", 7),
+		AppLogFileMaxAge:     getIntOrDefault("APP_LOG_FILE_MAX_AGE", 30),
+
+		AccessLogSampleRate:    getFloatOrDefault("ACCESS_LOG_SAMPLE_RATE", 0.1),
+		AccessLogSlowThreshold: getDurationOrDefault("ACCESS_LOG_SLOW_THRESHOLD", time.Second),
+
 		AuthJWTSecret:              viper.GetString("AUTH_JWT_SECRET"),
 		AuthAccessTokenExpiration:  AuthAccessTokenExpiration,
 		AuthRefreshTokenExpiration: AuthRefreshTokenExpiration,
@@ -98,11 +129,47 @@ func LoadConfig() (Config, error) {
 		StorageUseSSL:    viper.GetBool("STORAGE_USE_SSL"),
 
 		SentryDSN: viper.GetString("SENTRY_DSN"),
+
+		RetentionStatusLogDays:    getIntOrDefault("RETENTION_STATUS_LOG_DAYS", 30),
+		RetentionHourlyRollupDays: getIntOrDefault("RETENTION_HOURLY_ROLLUP_DAYS", 400),
+
+		MetricsWorkerPort:    getIntOrDefault("METRICS_WORKER_PORT", 9091),
+		MetricsSchedulerPort: getIntOrDefault("METRICS_SCHEDULER_PORT", 9092),
+
+		TracingOTLPEndpoint: viper.GetString("TRACING_OTLP_ENDPOINT"),
+		TracingSampleRatio:  getFloatOrDefault("TRACING_SAMPLE_RATIO", 1),
+
+		HealthHeartbeatMaxAge: getDurationOrDefault("HEALTH_HEARTBEAT_MAX_AGE", 5*time.Minute),
+
+		ShutdownTimeout: getDurationOrDefault("SHUTDOWN_TIMEOUT", 30*time.Second),
 	}
 
 	return cfg, nil
 }
 
+// getIntOrDefault treats a missing or empty key as unset, since .env.example ships every key empty.
+func getIntOrDefault(key string, fallback int) int {
+	if viper.GetString(key) == "" {
+		return fallback
+	}
+	return viper.GetInt(key)
+}
+
+func getFloatOrDefault(key string, fallback float64) float64 {
+	if viper.GetString(key) == "" {
+		return fallback
+	}
+	return viper.GetFloat64(key)
+}
+
+func getDurationOrDefault(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(viper.GetString(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
 func (c *Config) DBDSN() string {
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
@@ -0,0 +1,26 @@
+package db
+
+import (
+	"context"
+	"io"
+	"uptimatic/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// Close releases a client on shutdown. The error is only logged, since the process is exiting
+// and there is nothing left to do about it.
+func Close(name string, client io.Closer) {
+	if err := client.Close(); err != nil {
+		utils.Error(context.Background(), "Failed to close client", map[string]any{"client": name, "error": err.Error()})
+	}
+}
+
+func ClosePostgres(db *gorm.DB) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		utils.Error(context.Background(), "Failed to close client", map[string]any{"client": "postgres", "error": err.Error()})
+		return
+	}
+	Close("postgres", sqlDB)
+}
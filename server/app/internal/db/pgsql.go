@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"uptimatic/internal/config"
+	"uptimatic/internal/tracing"
 	"uptimatic/internal/utils"
 
 	"gorm.io/driver/postgres"
@@ -14,5 +15,8 @@ func NewPostgresClient(cfg *config.Config) *gorm.DB {
 	if err != nil {
 		utils.Fatal(context.Background(), "Failed to connect postgres", map[string]any{"error": err})
 	}
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		utils.Fatal(context.Background(), "Failed to register tracing plugin", map[string]any{"error": err})
+	}
 	return db
 }
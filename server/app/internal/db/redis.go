@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"uptimatic/internal/config"
+	"uptimatic/internal/tracing"
 	"uptimatic/internal/utils"
 
 	"github.com/go-redis/redis/v8"
@@ -16,6 +17,7 @@ func NewRedisClient(cfg *config.Config) *redis.Client {
 		Password: cfg.RedisPass,
 		DB:       0,
 	})
+	rdb.AddHook(tracing.RedisHook{})
 	if err := rdb.Ping(context.Background()).Err(); err != nil {
 		utils.Fatal(context.Background(), "Failed to connect redis", map[string]any{"error": err})
 	}
@@ -27,19 +29,24 @@ func NewAsynqClient(cfg *config.Config) *asynq.Client {
 	return asynq.NewClient(asynq.RedisClientOpt{Addr: cfg.RedisHost + ":" + fmt.Sprint(cfg.RedisPort)})
 }
 
-func NewAsynqServer(cfg *config.Config) *asynq.Server {
+// NewAsynqServer builds the worker's task server; healthCheck is called with the result of every
+// periodic ping the server makes to Redis.
+func NewAsynqServer(cfg *config.Config, healthCheck func(error)) *asynq.Server {
 	return asynq.NewServer(
 		asynq.RedisClientOpt{Addr: cfg.RedisHost + ":" + fmt.Sprint(cfg.RedisPort)},
 		asynq.Config{
 			Concurrency: 10,
+			// In-flight checks get this long to finish on shutdown before they are requeued.
+			ShutdownTimeout: cfg.ShutdownTimeout,
+			HealthCheckFunc: healthCheck,
 		},
 	)
 }
 
-func NewAsynqScheduler(cfg *config.Config) *asynq.Scheduler {
+func NewAsynqScheduler(cfg *config.Config, postEnqueue func(*asynq.TaskInfo, error)) *asynq.Scheduler {
 	return asynq.NewScheduler(
 		asynq.RedisClientOpt{Addr: cfg.RedisHost + ":" + fmt.Sprint(cfg.RedisPort)},
-		&asynq.SchedulerOpts{},
+		&asynq.SchedulerOpts{PostEnqueueFunc: postEnqueue},
 	)
 }
 
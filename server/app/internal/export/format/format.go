@@ -0,0 +1,144 @@
+package format
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	CSV     = "csv"
+	NDJSON  = "ndjson"
+	Parquet = "parquet"
+)
+
+var contentTypes = map[string]string{
+	CSV:     "text/csv; charset=utf-8",
+	NDJSON:  "application/x-ndjson",
+	Parquet: "application/vnd.apache.parquet",
+}
+
+// ContentType returns the MIME type of a format, or an empty string for unknown formats.
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+type ColumnType int
+
+const (
+	ColumnString ColumnType = iota
+	ColumnInt32
+	ColumnInt64
+	ColumnBool
+	ColumnTimestamp
+)
+
+type Column struct {
+	Name     string
+	Type     ColumnType
+	Optional bool
+}
+
+// Writer writes rows whose values follow the column types: string, int32, int64, bool,
+// time.Time, or nil for an optional column without a value.
+type Writer interface {
+	Write(row []any) error
+	Close() error
+}
+
+func NewWriter(format string, w io.Writer, columns []Column) (Writer, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w, columns)
+	case NDJSON:
+		return &ndjsonWriter{w: bufio.NewWriter(w), columns: columns}, nil
+	case Parquet:
+		return newParquetWriter(w, columns)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, columns []Column) (*csvWriter, error) {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+	return &csvWriter{cw}, nil
+}
+
+func (c *csvWriter) Write(row []any) error {
+	record := make([]string, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			record[i] = v
+		case int32:
+			record[i] = strconv.FormatInt(int64(v), 10)
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		case bool:
+			record[i] = strconv.FormatBool(v)
+		case time.Time:
+			record[i] = v.UTC().Format(time.RFC3339Nano)
+		default:
+			return fmt.Errorf("unexpected value of type %T", value)
+		}
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ndjsonWriter writes one JSON object per line with keys in column order.
+type ndjsonWriter struct {
+	w       *bufio.Writer
+	columns []Column
+}
+
+func (n *ndjsonWriter) Write(row []any) error {
+	n.w.WriteByte('{')
+	for i, value := range row {
+		if i > 0 {
+			n.w.WriteByte(',')
+		}
+		if t, ok := value.(time.Time); ok {
+			value = t.UTC()
+		}
+
+		key, err := json.Marshal(n.columns[i].Name)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		n.w.Write(key)
+		n.w.WriteByte(':')
+		n.w.Write(data)
+	}
+	n.w.WriteByte('}')
+	return n.w.WriteByte('\n')
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.w.Flush()
+}
@@ -0,0 +1,134 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetRowGroupSize bounds how many rows are buffered in memory before a row group is flushed.
+const parquetRowGroupSize = 50000
+
+// parquetNodes maps column types to the Parquet leaf written for them.
+var parquetNodes = map[ColumnType]func() parquet.Node{
+	ColumnString:    parquet.String,
+	ColumnInt32:     func() parquet.Node { return parquet.Int(32) },
+	ColumnInt64:     func() parquet.Node { return parquet.Int(64) },
+	ColumnBool:      func() parquet.Node { return parquet.Leaf(parquet.BooleanType) },
+	ColumnTimestamp: func() parquet.Node { return parquet.Timestamp(parquet.Millisecond) },
+}
+
+// orderedGroup is a parquet.Group that keeps its fields in column order instead of sorting them
+// by name, so Parquet exports list columns in the same order as CSV and NDJSON.
+type orderedGroup struct {
+	parquet.Group
+	fields []parquet.Field
+}
+
+func (g orderedGroup) Fields() []parquet.Field {
+	return g.fields
+}
+
+// parquetWriter writes a flat schema with Snappy compressed pages through parquet-go.
+type parquetWriter struct {
+	w       *parquet.Writer
+	columns []Column
+	row     parquet.Row
+}
+
+func newParquetWriter(w io.Writer, columns []Column) (*parquetWriter, error) {
+	group := parquet.Group{}
+	for _, column := range columns {
+		node, ok := parquetNodes[column.Type]
+		if !ok {
+			return nil, fmt.Errorf("unsupported type for column %s", column.Name)
+		}
+		if column.Optional {
+			group[column.Name] = parquet.Optional(node())
+		} else {
+			group[column.Name] = node()
+		}
+	}
+
+	byName := map[string]parquet.Field{}
+	for _, field := range group.Fields() {
+		byName[field.Name()] = field
+	}
+	fields := make([]parquet.Field, len(columns))
+	for i, column := range columns {
+		fields[i] = byName[column.Name]
+	}
+
+	schema := parquet.NewSchema("row", orderedGroup{Group: group, fields: fields})
+	return &parquetWriter{
+		w: parquet.NewWriter(w, schema,
+			parquet.Compression(&parquet.Snappy),
+			parquet.MaxRowsPerRowGroup(parquetRowGroupSize),
+		),
+		columns: columns,
+		row:     make(parquet.Row, len(columns)),
+	}, nil
+}
+
+func (p *parquetWriter) Write(row []any) error {
+	if len(row) != len(p.columns) {
+		return fmt.Errorf("expected %d values, got %d", len(p.columns), len(row))
+	}
+
+	for i, column := range p.columns {
+		if row[i] == nil {
+			if !column.Optional {
+				return fmt.Errorf("column %s is required", column.Name)
+			}
+			p.row[i] = parquet.NullValue().Level(0, 0, i)
+			continue
+		}
+
+		value, err := parquetValue(column, row[i])
+		if err != nil {
+			return err
+		}
+		// Optional columns need definition level 1 for a present value, required ones have none.
+		definitionLevel := 0
+		if column.Optional {
+			definitionLevel = 1
+		}
+		p.row[i] = value.Level(0, definitionLevel, i)
+	}
+
+	_, err := p.w.WriteRows([]parquet.Row{p.row})
+	return err
+}
+
+func (p *parquetWriter) Close() error {
+	return p.w.Close()
+}
+
+// parquetValue converts a row value to the Parquet value of its column.
+func parquetValue(column Column, value any) (parquet.Value, error) {
+	switch v := value.(type) {
+	case string:
+		if column.Type == ColumnString {
+			return parquet.ByteArrayValue([]byte(v)), nil
+		}
+	case int32:
+		if column.Type == ColumnInt32 {
+			return parquet.Int32Value(v), nil
+		}
+	case int64:
+		if column.Type == ColumnInt64 {
+			return parquet.Int64Value(v), nil
+		}
+	case bool:
+		if column.Type == ColumnBool {
+			return parquet.BooleanValue(v), nil
+		}
+	case time.Time:
+		if column.Type == ColumnTimestamp {
+			return parquet.Int64Value(v.UnixMilli()), nil
+		}
+	}
+	return parquet.Value{}, fmt.Errorf("unexpected value of type %T for column %s", value, column.Name)
+}
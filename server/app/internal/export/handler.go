@@ -0,0 +1,95 @@
+package export
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"uptimatic/internal/export/format"
+	"uptimatic/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ExportHandler interface {
+	ExportHandler(c *gin.Context)
+}
+
+type exportHandler struct {
+	exportService ExportService
+}
+
+func NewExportHandler(exportService ExportService) ExportHandler {
+	return &exportHandler{exportService}
+}
+
+func (h *exportHandler) ExportHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	query := ExportQuery{
+		Kind:   c.DefaultQuery("type", KindLogs),
+		Format: c.DefaultQuery("format", format.CSV),
+		To:     time.Now(),
+	}
+
+	from, err := time.Parse(time.RFC3339, c.Query("from"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid from", err))
+		return
+	}
+	query.From = from
+
+	if v := c.Query("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid to", err))
+			return
+		}
+		query.To = to
+	}
+
+	if v := c.Query("async"); v != "" {
+		async, err := strconv.ParseBool(v)
+		if err != nil {
+			utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid async", err))
+			return
+		}
+		query.Async = async
+	}
+
+	userID := c.GetUint("user_id")
+	export, errSvc := h.exportService.Prepare(c.Request.Context(), userID, id, &query)
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	if query.Async || export.Queued() {
+		if errSvc := h.exportService.Enqueue(c.Request.Context(), userID, export); errSvc != nil {
+			utils.ErrorResponse(c, errSvc)
+			return
+		}
+		utils.AcceptedResponse(c, ExportQueuedResponse{
+			Queued: true,
+			Kind:   export.Kind,
+			Format: export.Format,
+			From:   export.From,
+			To:     export.To,
+		})
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName()))
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so a failure mid-stream can only cut the body short.
+	if err := h.exportService.Write(c.Request.Context(), export, c.Writer); err != nil {
+		c.Abort()
+	}
+}
@@ -0,0 +1,17 @@
+package export
+
+import (
+	"uptimatic/internal/middleware"
+	"uptimatic/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ExportRoutes(r *gin.RouterGroup, h ExportHandler, jwtUtil *utils.JWTUtil) {
+	urls := r.Group("/urls")
+	urls.Use(middleware.AuthMiddleware(jwtUtil))
+	urls.Use(middleware.VerifiedMiddleware())
+	{
+		urls.GET("/:id/export", h.ExportHandler)
+	}
+}
@@ -0,0 +1,37 @@
+package export
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	KindLogs      = "logs"
+	KindIncidents = "incidents"
+)
+
+type ExportQuery struct {
+	Kind   string
+	Format string
+	From   time.Time
+	To     time.Time
+	Async  bool
+}
+
+type ExportPayload struct {
+	UserID uint      `json:"user_id"`
+	URLID  uuid.UUID `json:"url_id"`
+	Kind   string    `json:"kind"`
+	Format string    `json:"format"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+type ExportQueuedResponse struct {
+	Queued bool      `json:"queued"`
+	Kind   string    `json:"kind"`
+	Format string    `json:"format"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
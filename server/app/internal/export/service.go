@@ -0,0 +1,275 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"uptimatic/internal/adapters/email"
+	"uptimatic/internal/adapters/minio"
+	"uptimatic/internal/export/format"
+	"uptimatic/internal/models"
+	"uptimatic/internal/tasks"
+	"uptimatic/internal/url"
+	"uptimatic/internal/user"
+	"uptimatic/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+)
+
+const (
+	// syncExportMaxRange is the widest range streamed in the request; anything wider is built in the background.
+	syncExportMaxRange = 31 * 24 * time.Hour
+	exportLinkExpiry   = 24 * time.Hour
+	exportTaskTimeout  = time.Hour
+	// exportObjectPrefix holds queued exports; the bucket deletes them a day after upload, once
+	// their download link has expired.
+	exportObjectPrefix     = "exports/"
+	exportObjectExpiryDays = 1
+	exportLifecycleRule    = "expire-exports"
+)
+
+var exportColumns = map[string][]format.Column{
+	KindLogs: {
+		{Name: "checked_at", Type: format.ColumnTimestamp},
+		{Name: "status", Type: format.ColumnInt32},
+		{Name: "up", Type: format.ColumnBool},
+		{Name: "error_kind", Type: format.ColumnString, Optional: true},
+		{Name: "response_time_ms", Type: format.ColumnInt64},
+		{Name: "in_maintenance", Type: format.ColumnBool},
+		{Name: "dependent", Type: format.ColumnBool},
+	},
+	KindIncidents: {
+		{Name: "id", Type: format.ColumnString},
+		{Name: "status_code", Type: format.ColumnInt32},
+		{Name: "started_at", Type: format.ColumnTimestamp},
+		{Name: "resolved_at", Type: format.ColumnTimestamp, Optional: true},
+		{Name: "duration_seconds", Type: format.ColumnInt64, Optional: true},
+	},
+}
+
+var kindLabels = map[string]string{
+	KindLogs:      "riwayat pengecekan",
+	KindIncidents: "insiden",
+}
+
+// Export is a validated export request for a single monitor.
+type Export struct {
+	URL    *models.URL
+	Kind   string
+	Format string
+	From   time.Time
+	To     time.Time
+}
+
+func (e *Export) FileName() string {
+	return fmt.Sprintf("%s-%s-%s-%s.%s", e.URL.PublicID, e.Kind, e.From.UTC().Format("20060102T150405Z"), e.To.UTC().Format("20060102T150405Z"), e.Format)
+}
+
+func (e *Export) ContentType() string {
+	return format.ContentType(e.Format)
+}
+
+// Queued reports whether the export is too large to stream in the request.
+func (e *Export) Queued() bool {
+	return e.To.Sub(e.From) > syncExportMaxRange
+}
+
+type ExportService interface {
+	Prepare(ctx context.Context, userID uint, urlID uuid.UUID, query *ExportQuery) (*Export, *utils.AppError)
+	Write(ctx context.Context, export *Export, w io.Writer) error
+	Enqueue(ctx context.Context, userID uint, export *Export) *utils.AppError
+	Deliver(ctx context.Context, payload *ExportPayload, appUrl string) error
+	ExpireObjects(ctx context.Context) error
+}
+
+type exportService struct {
+	db           *gorm.DB
+	asyncClient  *asynq.Client
+	minio        *minio.MinioUtil
+	urlRepo      url.UrlRepository
+	logRepo      url.StatusLogRepository
+	incidentRepo url.IncidentRepository
+	userRepo     user.UserRepository
+}
+
+func NewExportService(db *gorm.DB, asyncClient *asynq.Client, minio *minio.MinioUtil, urlRepo url.UrlRepository, logRepo url.StatusLogRepository, incidentRepo url.IncidentRepository, userRepo user.UserRepository) ExportService {
+	return &exportService{db, asyncClient, minio, urlRepo, logRepo, incidentRepo, userRepo}
+}
+
+func (s *exportService) Prepare(ctx context.Context, userID uint, urlID uuid.UUID, query *ExportQuery) (*Export, *utils.AppError) {
+	if _, ok := exportColumns[query.Kind]; !ok {
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid type", nil)
+	}
+	if format.ContentType(query.Format) == "" {
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid format", nil)
+	}
+	if !query.From.Before(query.To) {
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "from must be before to", nil)
+	}
+
+	url, err := s.urlRepo.FindByPublicIDAndUserID(ctx, s.db, userID, urlID)
+	if err != nil {
+		utils.Warn(ctx, "URL not found for export", map[string]any{"url_id": urlID, "user_id": userID})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Url not found", err)
+	}
+
+	return &Export{
+		URL:    url,
+		Kind:   query.Kind,
+		Format: query.Format,
+		From:   query.From,
+		To:     query.To,
+	}, nil
+}
+
+// Write streams the export rows straight from the database into w in the requested format.
+func (s *exportService) Write(ctx context.Context, export *Export, w io.Writer) error {
+	rw, err := format.NewWriter(export.Format, w, exportColumns[export.Kind])
+	if err != nil {
+		return err
+	}
+
+	switch export.Kind {
+	case KindLogs:
+		err = s.logRepo.StreamByURLID(ctx, s.db, export.URL.ID, export.From, export.To, func(log *models.StatusLog) error {
+			return rw.Write(logRow(log))
+		})
+	case KindIncidents:
+		err = s.incidentRepo.StreamByURLID(ctx, s.db, export.URL.ID, export.From, export.To, func(incident *models.Incident) error {
+			return rw.Write(incidentRow(incident))
+		})
+	}
+	if err != nil {
+		utils.Error(ctx, "Failed to write export", map[string]any{"url_id": export.URL.PublicID, "kind": export.Kind, "format": export.Format, "err": err.Error()})
+		return err
+	}
+	return rw.Close()
+}
+
+func (s *exportService) Enqueue(ctx context.Context, userID uint, export *Export) *utils.AppError {
+	payload, err := json.Marshal(ExportPayload{
+		UserID: userID,
+		URLID:  export.URL.PublicID,
+		Kind:   export.Kind,
+		Format: export.Format,
+		From:   export.From,
+		To:     export.To,
+	})
+	if err != nil {
+		return utils.InternalServerError("Error queueing export", err)
+	}
+
+	if _, err := tasks.Enqueue(ctx, s.asyncClient, tasks.TaskExport, payload, asynq.MaxRetry(3), asynq.Timeout(exportTaskTimeout)); err != nil {
+		utils.Error(ctx, "Failed to enqueue export", map[string]any{"url_id": export.URL.PublicID, "err": err.Error()})
+		return utils.InternalServerError("Error queueing export", err)
+	}
+
+	utils.Info(ctx, "Export queued", map[string]any{"url_id": export.URL.PublicID, "user_id": userID, "kind": export.Kind, "format": export.Format})
+	return nil
+}
+
+// Deliver builds a queued export into the storage bucket and emails the requester a download link.
+func (s *exportService) Deliver(ctx context.Context, payload *ExportPayload, appUrl string) error {
+	export, appErr := s.Prepare(ctx, payload.UserID, payload.URLID, &ExportQuery{
+		Kind:   payload.Kind,
+		Format: payload.Format,
+		From:   payload.From,
+		To:     payload.To,
+	})
+	if appErr != nil {
+		// The monitor is gone or the payload is invalid; retrying will not help.
+		return fmt.Errorf("%s: %w", appErr.Message, asynq.SkipRetry)
+	}
+
+	owner, err := s.userRepo.FindByID(ctx, s.db, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	objectName := fmt.Sprintf("%s%d/%s.%s", exportObjectPrefix, owner.ID, uuid.New(), export.Format)
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(s.Write(ctx, export, writer))
+	}()
+	if err := s.minio.UploadStream(ctx, reader, objectName, export.ContentType()); err != nil {
+		reader.CloseWithError(err)
+		return fmt.Errorf("failed to upload export: %w", err)
+	}
+
+	link, err := s.minio.GetPresignedDownloadURL(ctx, objectName, export.FileName(), exportLinkExpiry)
+	if err != nil {
+		return fmt.Errorf("failed to sign export link: %w", err)
+	}
+
+	loc, err := time.LoadLocation(owner.Timezone)
+	if err != nil {
+		loc, _ = time.LoadLocation(utils.DefaultTimezone)
+	}
+
+	err = tasks.EnqueueEmail(ctx, s.asyncClient, owner.Email, fmt.Sprintf("Your export for %s is ready", export.URL.Label), email.EmailExportReady, map[string]any{
+		"LogoURL":      fmt.Sprintf("%s/icon.png", appUrl),
+		"Label":        export.URL.Label,
+		"Kind":         kindLabels[export.Kind],
+		"Format":       strings.ToUpper(export.Format),
+		"From":         export.From.In(loc).Format("02 Jan 2006 15:04 MST"),
+		"To":           export.To.In(loc).Format("02 Jan 2006 15:04 MST"),
+		"DownloadLink": link,
+		"ExpiresIn":    fmt.Sprintf("%d jam", int(exportLinkExpiry.Hours())),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue export email: %w", err)
+	}
+
+	utils.Info(ctx, "Export delivered", map[string]any{"url_id": export.URL.PublicID, "user_id": owner.ID, "object": objectName})
+	return nil
+}
+
+// ExpireObjects makes the bucket delete queued exports after their download link has expired.
+func (s *exportService) ExpireObjects(ctx context.Context) error {
+	if err := s.minio.ExpirePrefix(ctx, exportLifecycleRule, exportObjectPrefix, exportObjectExpiryDays); err != nil {
+		return fmt.Errorf("failed to set export lifecycle rule: %w", err)
+	}
+	return nil
+}
+
+func logRow(log *models.StatusLog) []any {
+	status, _ := strconv.Atoi(log.Status)
+
+	var errorKind any
+	if kind := url.LogErrorKind(log); kind != "" {
+		errorKind = kind
+	}
+
+	return []any{
+		log.CheckedAt,
+		int32(status),
+		errorKind == nil,
+		errorKind,
+		log.ResponseTime,
+		log.InMaintenance,
+		log.Dependent,
+	}
+}
+
+func incidentRow(incident *models.Incident) []any {
+	var resolvedAt, duration any
+	if incident.ResolvedAt != nil {
+		resolvedAt = *incident.ResolvedAt
+		duration = int64(incident.ResolvedAt.Sub(incident.StartedAt).Seconds())
+	}
+
+	return []any{
+		incident.PublicID.String(),
+		int32(incident.StatusCode),
+		incident.StartedAt,
+		resolvedAt,
+		duration,
+	}
+}
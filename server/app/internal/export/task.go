@@ -0,0 +1,37 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"uptimatic/internal/config"
+	"uptimatic/internal/utils"
+
+	"github.com/hibiken/asynq"
+)
+
+type TaskHandler struct {
+	cfg           *config.Config
+	exportService ExportService
+}
+
+func NewTaskHandler(cfg *config.Config, exportService ExportService) *TaskHandler {
+	return &TaskHandler{cfg, exportService}
+}
+
+func (h *TaskHandler) ExportHandler(ctx context.Context, t *asynq.Task) error {
+	var payload ExportPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		utils.Error(ctx, "Failed to unmarshal export payload", map[string]any{"error": err.Error()})
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	utils.Info(ctx, "Building export", map[string]any{"url_id": payload.URLID, "user_id": payload.UserID, "kind": payload.Kind, "format": payload.Format})
+
+	appUrl := fmt.Sprintf("%s://%s", h.cfg.AppScheme, h.cfg.AppDomain)
+	if err := h.exportService.Deliver(ctx, &payload, appUrl); err != nil {
+		utils.Error(ctx, "Failed to deliver export", map[string]any{"url_id": payload.URLID, "user_id": payload.UserID, "error": err.Error()})
+		return err
+	}
+	return nil
+}
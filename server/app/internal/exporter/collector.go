@@ -0,0 +1,92 @@
+package exporter
+
+import (
+	"uptimatic/internal/models"
+	"uptimatic/internal/url"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var monitorLabels = []string{"public_id", "label"}
+
+var (
+	upDesc = prometheus.NewDesc(
+		"uptimatic_monitor_up",
+		"Whether the last check of the monitor succeeded (1) or failed (0).",
+		monitorLabels, nil,
+	)
+	responseTimeDesc = prometheus.NewDesc(
+		"uptimatic_monitor_response_time_seconds",
+		"Response time of the last check.",
+		monitorLabels, nil,
+	)
+	statusCodeDesc = prometheus.NewDesc(
+		"uptimatic_monitor_status_code",
+		"HTTP status code of the last check, 0 when no response was received.",
+		monitorLabels, nil,
+	)
+	lastCheckDesc = prometheus.NewDesc(
+		"uptimatic_monitor_last_check_timestamp_seconds",
+		"Unix time of the last check.",
+		monitorLabels, nil,
+	)
+	certExpiryDesc = prometheus.NewDesc(
+		"uptimatic_monitor_certificate_expiry_timestamp_seconds",
+		"Unix time at which the TLS certificate seen by the last check expires.",
+		monitorLabels, nil,
+	)
+	slaRemainingDesc = prometheus.NewDesc(
+		"uptimatic_monitor_sla_remaining_budget_seconds",
+		"Downtime left in the error budget of the monitor's SLA window.",
+		monitorLabels, nil,
+	)
+	slaConsumedDesc = prometheus.NewDesc(
+		"uptimatic_monitor_sla_budget_consumed_percent",
+		"Share of the SLA error budget already used.",
+		monitorLabels, nil,
+	)
+)
+
+// monitorCollector serves a snapshot of one account's cached monitor results. Monitors the
+// worker has not reported on yet are left out rather than exported as zero.
+type monitorCollector struct {
+	urls    []models.URL
+	metrics map[uint]url.MonitorMetrics
+}
+
+func (c *monitorCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{upDesc, responseTimeDesc, statusCodeDesc, lastCheckDesc, certExpiryDesc, slaRemainingDesc, slaConsumedDesc} {
+		ch <- desc
+	}
+}
+
+func (c *monitorCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, u := range c.urls {
+		metrics, ok := c.metrics[u.ID]
+		if !ok {
+			continue
+		}
+		labels := []string{u.PublicID.String(), u.Label}
+
+		if check := metrics.Check; check != nil {
+			up := 0.0
+			if check.Up {
+				up = 1
+			}
+			ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, up, labels...)
+			ch <- prometheus.MustNewConstMetric(statusCodeDesc, prometheus.GaugeValue, float64(check.StatusCode), labels...)
+			ch <- prometheus.MustNewConstMetric(lastCheckDesc, prometheus.GaugeValue, float64(check.CheckedAt.Unix()), labels...)
+			if check.StatusCode != 0 {
+				ch <- prometheus.MustNewConstMetric(responseTimeDesc, prometheus.GaugeValue, float64(check.ResponseTime)/1000, labels...)
+			}
+			if check.CertExpiry != nil {
+				ch <- prometheus.MustNewConstMetric(certExpiryDesc, prometheus.GaugeValue, float64(check.CertExpiry.Unix()), labels...)
+			}
+		}
+
+		if sla := metrics.SLA; sla != nil {
+			ch <- prometheus.MustNewConstMetric(slaRemainingDesc, prometheus.GaugeValue, float64(sla.RemainingBudgetSeconds), labels...)
+			ch <- prometheus.MustNewConstMetric(slaConsumedDesc, prometheus.GaugeValue, sla.BudgetConsumedPercent, labels...)
+		}
+	}
+}
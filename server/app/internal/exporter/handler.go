@@ -0,0 +1,43 @@
+package exporter
+
+import (
+	"net/http"
+	"strings"
+	"uptimatic/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type ExporterHandler interface {
+	MonitorsHandler(c *gin.Context)
+}
+
+type exporterHandler struct {
+	exporterService ExporterService
+}
+
+func NewExporterHandler(exporterService ExporterService) ExporterHandler {
+	return &exporterHandler{exporterService}
+}
+
+// MonitorsHandler serves the account's monitor results in the Prometheus exposition format. It is
+// authenticated with the metrics token as a bearer token, which Prometheus can send on its own.
+func (h *exporterHandler) MonitorsHandler(c *gin.Context) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusUnauthorized, utils.Unauthorized, "Missing metrics token", nil))
+		return
+	}
+
+	collector, err := h.exporterService.MonitorCollector(c.Request.Context(), token)
+	if err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,12 @@
+package exporter
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func ExporterRoutes(r *gin.RouterGroup, h ExporterHandler) {
+	metrics := r.Group("/metrics")
+	{
+		metrics.GET("/monitors", h.MonitorsHandler)
+	}
+}
@@ -0,0 +1,61 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"uptimatic/internal/url"
+	"uptimatic/internal/user"
+	"uptimatic/internal/utils"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+type ExporterService interface {
+	MonitorCollector(ctx context.Context, token string) (prometheus.Collector, *utils.AppError)
+}
+
+type exporterService struct {
+	db           *gorm.DB
+	userRepo     user.UserRepository
+	urlRepo      url.UrlRepository
+	metricsCache url.MonitorMetricsCache
+}
+
+func NewExporterService(db *gorm.DB, userRepo user.UserRepository, urlRepo url.UrlRepository, metricsCache url.MonitorMetricsCache) ExporterService {
+	return &exporterService{db, userRepo, urlRepo, metricsCache}
+}
+
+// MonitorCollector resolves the metrics token to its account and snapshots the cached results of
+// every monitor in it.
+func (s *exporterService) MonitorCollector(ctx context.Context, token string) (prometheus.Collector, *utils.AppError) {
+	owner, err := s.userRepo.FindByMetricsTokenHash(ctx, s.db, user.HashMetricsToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.Warn(ctx, "Invalid metrics token", nil)
+			return nil, utils.NewAppError(http.StatusUnauthorized, utils.InvalidToken, "Invalid metrics token", err)
+		}
+		utils.Error(ctx, "Failed to find metrics token", map[string]any{"err": err.Error()})
+		return nil, utils.InternalServerError("Error finding token", err)
+	}
+
+	urls, err := s.urlRepo.ListAllByUserID(ctx, s.db, owner.ID)
+	if err != nil {
+		utils.Error(ctx, "Failed to list URLs", map[string]any{"user_id": owner.ID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error listing urls", err)
+	}
+
+	urlIDs := make([]uint, 0, len(urls))
+	for _, u := range urls {
+		urlIDs = append(urlIDs, u.ID)
+	}
+	metrics, err := s.metricsCache.GetMany(ctx, urlIDs)
+	if err != nil {
+		utils.Error(ctx, "Failed to read monitor metrics", map[string]any{"user_id": owner.ID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error reading metrics", err)
+	}
+
+	utils.Debug(ctx, "Monitor metrics scraped", map[string]any{"user_id": owner.ID, "monitors": len(urls), "cached": len(metrics)})
+	return &monitorCollector{urls, metrics}, nil
+}
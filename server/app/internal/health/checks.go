@@ -0,0 +1,33 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+)
+
+func Postgres(db *gorm.DB) Check {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return fmt.Errorf("failed to get database handle: %w", err)
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+func Redis(rdb *redis.Client) Check {
+	return func(ctx context.Context) error {
+		return rdb.Ping(ctx).Err()
+	}
+}
+
+// Asynq pings the Redis behind the task queue, which may differ from the cache connection.
+func Asynq(client *asynq.Client) Check {
+	return func(ctx context.Context) error {
+		return client.Ping()
+	}
+}
@@ -0,0 +1,62 @@
+package health
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// started stands in for heartbeats that have not happened yet, so a process that just came up
+// gets a full max age before it is reported as stuck.
+var started = time.Now()
+
+var heartbeats = struct {
+	sync.Mutex
+	last map[string]time.Time
+}{last: map[string]time.Time{}}
+
+type HeartbeatResult struct {
+	Status      string     `json:"status"`
+	LastSuccess *time.Time `json:"last_success"`
+	AgeSeconds  int64      `json:"age_seconds"`
+}
+
+// Beat records a successful run of name in this process.
+func Beat(name string) {
+	heartbeats.Lock()
+	defer heartbeats.Unlock()
+	heartbeats.last[name] = time.Now()
+}
+
+// Heartbeats reports the last beat of every name in this process, failing the ones older than
+// maxAge.
+func Heartbeats(maxAge time.Duration, names ...string) Report {
+	heartbeats.Lock()
+	defer heartbeats.Unlock()
+
+	now := time.Now()
+	report := Report{Status: StatusOK, Heartbeats: make(map[string]HeartbeatResult, len(names))}
+	for _, name := range names {
+		result := HeartbeatResult{Status: StatusOK}
+		since := started
+		if last, ok := heartbeats.last[name]; ok {
+			result.LastSuccess = &last
+			since = last
+		}
+		result.AgeSeconds = int64(now.Sub(since).Seconds())
+		if now.Sub(since) > maxAge {
+			result.Status = StatusFail
+			report.Status = StatusFail
+		}
+		report.Heartbeats[name] = result
+	}
+	return report
+}
+
+// HeartbeatHandler is the liveness endpoint of the processes that have no HTTP server. It answers
+// 503 once any of the named heartbeats is older than maxAge.
+func HeartbeatHandler(maxAge time.Duration, names ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, Heartbeats(maxAge, names...))
+	})
+}
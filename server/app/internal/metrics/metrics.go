@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"uptimatic/internal/utils"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "uptimatic"
+
+// Result label values shared by the task and email counters.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Check state label values, one per way a check can be recorded.
+const (
+	CheckUp          = "up"
+	CheckDown        = "down"
+	CheckMaintenance = "maintenance"
+	CheckDependent   = "dependent"
+	CheckError       = "error"
+)
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of API requests by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	TasksProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_processed_total",
+		Help:      "Background tasks processed by type and result.",
+	}, []string{"type", "result"})
+
+	TaskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "task_duration_seconds",
+		Help:      "Time spent processing background tasks by type.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300, 1800},
+	}, []string{"type"})
+
+	ChecksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "checks_total",
+		Help:      "Uptime checks performed by resulting monitor state.",
+	}, []string{"state"})
+
+	EmailsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "emails_sent_total",
+		Help:      "Emails sent by type and result.",
+	}, []string{"type", "result"})
+)
+
+// Handler serves every registered metric in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Serve exposes /metrics, along with extra handlers such as health checks, on its own listener for
+// the commands that have no HTTP server. It runs until ctx is done; a port of 0 disables it.
+func Serve(ctx context.Context, port int, extra map[string]http.Handler) {
+	if port == 0 {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	for pattern, handler := range extra {
+		mux.Handle(pattern, handler)
+	}
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		utils.Info(ctx, "Metrics listener started", map[string]any{"addr": srv.Addr})
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			utils.Error(ctx, "Metrics listener stopped", map[string]any{"error": err.Error()})
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			utils.Warn(context.Background(), "Failed to stop metrics listener", map[string]any{"error": err.Error()})
+		}
+	}()
+}
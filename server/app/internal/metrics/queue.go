@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"uptimatic/internal/utils"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var queueTasksDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "queue", "tasks"),
+	"Tasks in each asynq queue by state.",
+	[]string{"queue", "state"}, nil,
+)
+
+// queueCollector reads queue depth from Redis on every scrape, so the numbers are never stale.
+type queueCollector struct {
+	inspector *asynq.Inspector
+}
+
+// RegisterQueueCollector adds the queue depth gauges. Every process sees the same queues, so it
+// should only be registered in one of them.
+func RegisterQueueCollector(inspector *asynq.Inspector) error {
+	return prometheus.Register(&queueCollector{inspector})
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueTasksDesc
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	queues, err := c.inspector.Queues()
+	if err != nil {
+		utils.Error(context.Background(), "Failed to list queues", map[string]any{"error": err.Error()})
+		return
+	}
+
+	for _, queue := range queues {
+		info, err := c.inspector.GetQueueInfo(queue)
+		if err != nil {
+			utils.Error(context.Background(), "Failed to get queue info", map[string]any{"queue": queue, "error": err.Error()})
+			continue
+		}
+
+		for state, count := range map[string]int{
+			"pending":   info.Pending,
+			"active":    info.Active,
+			"scheduled": info.Scheduled,
+			"retry":     info.Retry,
+			"archived":  info.Archived,
+		} {
+			ch <- prometheus.MustNewConstMetric(queueTasksDesc, prometheus.GaugeValue, float64(count), queue, state)
+		}
+	}
+}
@@ -2,20 +2,73 @@ package middleware
 
 import (
 	"context"
+	"math/rand/v2"
+	"time"
 	"uptimatic/internal/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// maxRequestIDLength caps client supplied request IDs so they cannot flood the logs.
+const maxRequestIDLength = 128
+
+// RequestID takes the request ID from the X-Request-ID header, or generates one, stores it in
+// the request context for logging and echoes it back so clients can quote it in bug reports.
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.Request.Header.Get("X-Request-ID")
-		if requestID == "" {
+		if requestID == "" || len(requestID) > maxRequestIDLength {
 			requestID = uuid.New().String()
 		}
 		ctx := context.WithValue(c.Request.Context(), utils.TraceKey, requestID)
 		c.Request = c.Request.WithContext(ctx)
+		c.Header("X-Request-ID", requestID)
+		c.Next()
+	}
+}
+
+// AccessLog writes one log line per request. Failed requests and requests slower than
+// slowThreshold are always logged, other requests only for a sampleRate share of the traffic.
+func AccessLog(sampleRate float64, slowThreshold time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
 		c.Next()
+
+		latency := time.Since(start)
+		status := c.Writer.Status()
+		slow := slowThreshold > 0 && latency >= slowThreshold
+		failed := status >= 400 || len(c.Errors) > 0
+		if !slow && !failed && rand.Float64() >= sampleRate {
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		fields := map[string]any{
+			"method":     c.Request.Method,
+			"route":      route,
+			"status":     status,
+			"latency_ms": float64(latency.Microseconds()) / 1000,
+			"bytes":      max(c.Writer.Size(), 0),
+			"client_ip":  c.ClientIP(),
+			"user_agent": c.Request.UserAgent(),
+			"slow":       slow,
+		}
+		if userID, ok := c.Get("user_id"); ok {
+			fields["user_id"] = userID
+		}
+		if len(c.Errors) > 0 {
+			fields["errors"] = c.Errors.String()
+		}
+
+		ctx := c.Request.Context()
+		if status >= 500 || slow {
+			utils.Warn(ctx, "HTTP request", fields)
+			return
+		}
+		utils.Info(ctx, "HTTP request", fields)
 	}
 }
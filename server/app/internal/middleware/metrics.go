@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+	"uptimatic/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics records request latency labelled by the route template rather than the raw path, so
+// public IDs in URLs do not blow up the number of series.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusLogArchive records one month of a monitor's status logs stored in the bucket.
+type StatusLogArchive struct {
+	ID          uint      `gorm:"primary_key"`
+	UserID      uint      `gorm:"not null"`
+	URLID       uint      `gorm:"not null"`
+	URLPublicID uuid.UUID `gorm:"not null"`
+	Month       time.Time `gorm:"not null"`
+	ObjectKey   string    `gorm:"not null"`
+	RowCount    int64     `gorm:"not null"`
+	ArchivedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+type RestoredStatusLog struct {
+	ID            uint      `gorm:"primary_key" json:"id"`
+	URLID         uint      `gorm:"not null" json:"-"`
+	Status        int       `gorm:"not null" json:"status"`
+	ResponseTime  int64     `json:"response_time"`
+	InMaintenance bool      `gorm:"not null;default:false" json:"in_maintenance"`
+	Dependent     bool      `gorm:"not null;default:false" json:"dependent"`
+	DNSTime       *int64    `json:"dns_time"`
+	ConnectTime   *int64    `json:"connect_time"`
+	TLSTime       *int64    `json:"tls_time"`
+	TTFBTime      *int64    `json:"ttfb_time"`
+	TransferTime  *int64    `json:"transfer_time"`
+	RemoteIP      *string   `json:"remote_ip"`
+	Protocol      *string   `json:"protocol"`
+	ResponseSize  *int64    `json:"response_size"`
+	ErrorKind     *string   `json:"error_kind"`
+	CheckedAt     time.Time `gorm:"primary_key" json:"checked_at"`
+	RestoredAt    time.Time `gorm:"autoCreateTime" json:"-"`
+}
+
+func (RestoredStatusLog) TableName() string {
+	return "status_logs_restored"
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Incident struct {
+	ID         uint       `gorm:"primary_key"`
+	PublicID   uuid.UUID  `gorm:"not null;unique"`
+	URLID      uint       `gorm:"not null"`
+	StatusCode int        `gorm:"not null"`
+	StartedAt  time.Time  `gorm:"not null"`
+	ResolvedAt *time.Time `gorm:"null"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime"`
+}
+
+// IncidentSummary aggregates a monitor's incidents clipped to a reporting period.
+type IncidentSummary struct {
+	URLID                uint
+	IncidentCount        int
+	DowntimeSeconds      int64
+	LongestOutageSeconds int64
+}
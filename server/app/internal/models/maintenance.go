@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RecurrenceOnce  = "once"
+	RecurrenceCron  = "cron"
+	RecurrenceRRule = "rrule"
+)
+
+type MaintenanceWindow struct {
+	ID              uint       `gorm:"primary_key"`
+	PublicID        uuid.UUID  `gorm:"not null;unique"`
+	UserID          uint       `gorm:"not null"`
+	Title           string     `gorm:"not null"`
+	Description     string     `gorm:"null"`
+	StartsAt        time.Time  `gorm:"not null"`
+	EndsAt          *time.Time `gorm:"null"`
+	RecurrenceType  string     `gorm:"not null"`
+	Recurrence      string     `gorm:"null"`
+	DurationMinutes int        `gorm:"not null"`
+	Timezone        string     `gorm:"not null"`
+	Active          bool       `gorm:"not null"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime"`
+
+	URLs []URL `gorm:"many2many:maintenance_window_urls;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
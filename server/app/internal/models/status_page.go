@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	VisibilityPublic   = "public"
+	VisibilityUnlisted = "unlisted"
+	VisibilityPassword = "password"
+)
+
+type StatusPage struct {
+	ID          uint      `gorm:"primary_key"`
+	PublicID    uuid.UUID `gorm:"not null;unique"`
+	UserID      uint      `gorm:"not null"`
+	Slug        string    `gorm:"not null;unique"`
+	Title       string    `gorm:"not null"`
+	Description string    `gorm:"null"`
+	Logo        string    `gorm:"null"`
+	Visibility  string    `gorm:"not null"`
+	Password    string    `gorm:"null" json:"-"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+
+	Components []StatusPageComponent `gorm:"foreignKey:StatusPageID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+type StatusPageComponent struct {
+	ID           uint      `gorm:"primary_key"`
+	PublicID     uuid.UUID `gorm:"not null;unique"`
+	StatusPageID uint      `gorm:"not null"`
+	URLID        uint      `gorm:"not null"`
+	Name         string    `gorm:"not null"`
+	Position     int       `gorm:"not null"`
+
+	URL URL `gorm:"foreignKey:URLID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+const (
+	IncidentInvestigating = "investigating"
+	IncidentIdentified    = "identified"
+	IncidentMonitoring    = "monitoring"
+	IncidentResolved      = "resolved"
+)
+
+type StatusPageIncident struct {
+	ID           uint       `gorm:"primary_key"`
+	PublicID     uuid.UUID  `gorm:"not null;unique"`
+	StatusPageID uint       `gorm:"not null"`
+	Title        string     `gorm:"not null"`
+	Status       string     `gorm:"not null"`
+	ResolvedAt   *time.Time `gorm:"null"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime"`
+
+	Components []StatusPageComponent      `gorm:"many2many:status_page_incident_components;"`
+	Updates    []StatusPageIncidentUpdate `gorm:"foreignKey:StatusPageIncidentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+type StatusPageIncidentUpdate struct {
+	ID                   uint      `gorm:"primary_key"`
+	PublicID             uuid.UUID `gorm:"not null;unique"`
+	StatusPageIncidentID uint      `gorm:"not null"`
+	Status               string    `gorm:"not null"`
+	Message              string    `gorm:"not null"`
+	CreatedAt            time.Time `gorm:"autoCreateTime"`
+}
+
+type StatusPageSubscriber struct {
+	ID               uint       `gorm:"primary_key"`
+	PublicID         uuid.UUID  `gorm:"not null;unique"`
+	StatusPageID     uint       `gorm:"not null"`
+	Email            string     `gorm:"not null"`
+	Confirmed        bool       `gorm:"not null"`
+	ConfirmToken     uuid.UUID  `gorm:"not null;unique"`
+	UnsubscribeToken uuid.UUID  `gorm:"not null;unique"`
+	ConfirmedAt      *time.Time `gorm:"null"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime"`
+
+	ConfirmationSentAt *time.Time `gorm:"null"`
+
+	StatusPage StatusPage `gorm:"foreignKey:StatusPageID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
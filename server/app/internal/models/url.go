@@ -1,36 +1,190 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const (
+	SLAWindowRolling30d    = "rolling_30d"
+	SLAWindowCalendarMonth = "calendar_month"
+)
+
 type URL struct {
-	ID          uint       `gorm:"primary_key"`
-	PublicID    uuid.UUID  `gorm:"not null;unique"`
-	UserID      uint       `gorm:"not null"`
-	Label       string     `gorm:"not null"`
-	URL         string     `gorm:"not null"`
-	Interval    int        `gorm:"not null"`
-	Active      bool       `gorm:"not null"`
-	LastChecked *time.Time `gorm:"null"`
-	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+	ID           uint       `gorm:"primary_key"`
+	PublicID     uuid.UUID  `gorm:"not null;unique"`
+	UserID       uint       `gorm:"not null"`
+	Label        string     `gorm:"not null"`
+	URL          string     `gorm:"not null"`
+	Interval     int        `gorm:"not null"`
+	Active       bool       `gorm:"not null"`
+	BadgeVisible bool       `gorm:"not null;default:false"`
+	LastChecked  *time.Time `gorm:"null"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+
+	SLATarget          *float64   `gorm:"column:sla_target;null"`
+	SLAWindow          string     `gorm:"column:sla_window;not null;default:rolling_30d"`
+	SLAAlertThresholds Int64Array `gorm:"column:sla_alert_thresholds;not null"`
+
+	User    User  `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Parents []URL `gorm:"many2many:url_dependencies;joinForeignKey:URLID;joinReferences:ParentID" json:",omitempty"`
+}
+
+type URLDependency struct {
+	URLID    uint `gorm:"primaryKey"`
+	ParentID uint `gorm:"primaryKey"`
+}
 
-	User User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+func (URLDependency) TableName() string {
+	return "url_dependencies"
 }
 
+// SLAAlert marks an error budget threshold of a monitor that has already been alerted on.
+type SLAAlert struct {
+	URLID     uint      `gorm:"primaryKey"`
+	Threshold int64     `gorm:"primaryKey"`
+	AlertedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// StatusLog is one check. The phase timings are in milliseconds; they and the connection details
+// are nil for checks recorded before they were collected.
 type StatusLog struct {
-	ID           uint      `gorm:"primary_key"`
-	URLID        uint      `gorm:"not null"`
-	Status       string    `gorm:"not null"`
-	ResponseTime int64     `gorm:"not null"`
-	CheckedAt    time.Time `gorm:"autoCreateTime"`
+	ID            uint      `gorm:"primary_key"`
+	URLID         uint      `gorm:"not null"`
+	Status        string    `gorm:"not null"`
+	ResponseTime  int64     `gorm:"not null"`
+	InMaintenance bool      `gorm:"not null;default:false"`
+	Dependent     bool      `gorm:"not null;default:false"`
+	CheckedAt     time.Time `gorm:"autoCreateTime"`
+	DNSTime       *int64
+	ConnectTime   *int64
+	TLSTime       *int64
+	TTFBTime      *int64
+	TransferTime  *int64
+	RemoteIP      *string
+	Protocol      *string
+	ResponseSize  *int64
+	ErrorKind     *string
 }
 
 type UptimeStat struct {
-	BucketStart   time.Time `json:"bucket_start"`
-	TotalChecks   int       `json:"total_checks"`
-	UpChecks      int       `json:"up_checks"`
-	UptimePercent float64   `json:"uptime_percent"`
+	BucketStart       time.Time       `json:"bucket_start"`
+	TotalChecks       int             `json:"total_checks"`
+	UpChecks          int             `json:"up_checks"`
+	MaintenanceChecks int             `json:"maintenance_checks"`
+	UptimePercent     float64         `json:"uptime_percent"`
+	MinResponseTime   *float64        `json:"min_response_time,omitempty"`
+	AvgResponseTime   *float64        `json:"avg_response_time,omitempty"`
+	P50ResponseTime   *float64        `json:"p50_response_time,omitempty"`
+	P90ResponseTime   *float64        `json:"p90_response_time,omitempty"`
+	P95ResponseTime   *float64        `json:"p95_response_time,omitempty"`
+	P99ResponseTime   *float64        `json:"p99_response_time,omitempty"`
+	MaxResponseTime   *float64        `json:"max_response_time,omitempty"`
+	ErrorKinds        ErrorKindCounts `json:"error_kinds,omitempty"`
+	AvgDNSTime        *float64        `json:"avg_dns_time,omitempty"`
+	AvgConnectTime    *float64        `json:"avg_connect_time,omitempty"`
+	AvgTLSTime        *float64        `json:"avg_tls_time,omitempty"`
+	AvgTTFBTime       *float64        `json:"avg_ttfb_time,omitempty"`
+	AvgTransferTime   *float64        `json:"avg_transfer_time,omitempty"`
+}
+
+// ErrorKindCounts holds failed check counts keyed by error kind, scanned from a jsonb column.
+type ErrorKindCounts map[string]int
+
+func (e *ErrorKindCounts) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("unsupported error kinds type %T", value)
+	}
+}
+
+func (e ErrorKindCounts) Value() (driver.Value, error) {
+	if e == nil {
+		return nil, nil
+	}
+	return json.Marshal(e)
+}
+
+// Int64Array maps a Postgres bigint[] column.
+type Int64Array []int64
+
+// Histogram holds per-bucket counts scanned from a Postgres bigint[] column.
+type Histogram = Int64Array
+
+func (h *Int64Array) Scan(value any) error {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return fmt.Errorf("unsupported bigint array type %T", value)
+	}
+
+	text = strings.Trim(text, "{}")
+	if text == "" {
+		*h = Int64Array{}
+		return nil
+	}
+
+	parts := strings.Split(text, ",")
+	counts := make(Int64Array, len(parts))
+	for i, part := range parts {
+		if part == "NULL" {
+			continue
+		}
+		count, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid bigint array value %q: %w", part, err)
+		}
+		counts[i] = count
+	}
+	*h = counts
+	return nil
+}
+
+func (h Int64Array) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+	parts := make([]string, len(h))
+	for i, count := range h {
+		parts[i] = strconv.FormatInt(count, 10)
+	}
+	return "{" + strings.Join(parts, ",") + "}", nil
+}
+
+type URLUptimeStat struct {
+	URLID uint `json:"-"`
+	UptimeStat
+}
+
+type UptimeSummary struct {
+	TotalChecks       int     `json:"total_checks"`
+	UpChecks          int     `json:"up_checks"`
+	MaintenanceChecks int     `json:"maintenance_checks"`
+	UptimePercent     float64 `json:"uptime_percent"`
+	AvgResponseTime   float64 `json:"avg_response_time"`
+}
+
+type URLUptimeSummary struct {
+	URLID uint `json:"-"`
+	UptimeSummary
 }
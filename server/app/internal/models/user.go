@@ -2,6 +2,12 @@ package models
 
 import "time"
 
+const (
+	ReportScheduleNone    = "none"
+	ReportScheduleWeekly  = "weekly"
+	ReportScheduleMonthly = "monthly"
+)
+
 type User struct {
 	ID        uint      `gorm:"primary_key" json:"id"`
 	Name      string    `gorm:"not null" json:"name"`
@@ -9,5 +15,9 @@ type User struct {
 	Password  string    `gorm:"not null" json:"-"`
 	Verified  bool      `gorm:"not null" json:"verified"`
 	Profile   string    `json:"profile"`
+	Timezone  string    `gorm:"not null;default:Asia/Jakarta" json:"timezone"`
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	ReportSchedule   string  `gorm:"not null;default:none" json:"report_schedule"`
+	MetricsTokenHash *string `gorm:"unique" json:"-"`
 }
@@ -0,0 +1,254 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"uptimatic/internal/adapters/email"
+	"uptimatic/internal/models"
+	"uptimatic/internal/tasks"
+	"uptimatic/internal/utils"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	// reportHour is the local hour at which scheduled reports go out.
+	reportHour = 8
+	// reportSlowest is how many of the slowest monitors a report lists.
+	reportSlowest = 5
+)
+
+var reportLabels = map[string]string{
+	models.ReportScheduleWeekly:  "Mingguan",
+	models.ReportScheduleMonthly: "Bulanan",
+}
+
+// reportPeriod returns the period covered by a report sent at now, or false when none is due.
+// Weekly reports go out on Monday morning for the past Monday to Sunday, monthly reports on the
+// first of the month for the past month, both in the user's time zone.
+func reportPeriod(schedule string, now time.Time, loc *time.Location) (time.Time, time.Time, bool) {
+	local := now.In(loc)
+	if local.Hour() != reportHour {
+		return time.Time{}, time.Time{}, false
+	}
+
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	switch {
+	case schedule == models.ReportScheduleWeekly && local.Weekday() == time.Monday:
+		return today.AddDate(0, 0, -7), today, true
+	case schedule == models.ReportScheduleMonthly && local.Day() == 1:
+		return today.AddDate(0, -1, 0), today, true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// previousPeriod returns the period before [from, to) that a report compares against.
+func previousPeriod(schedule string, from time.Time) (time.Time, time.Time) {
+	if schedule == models.ReportScheduleMonthly {
+		return from.AddDate(0, -1, 0), from
+	}
+	return from.AddDate(0, 0, -7), from
+}
+
+// EnqueueDue queues a report task for every subscriber whose report is due at now. The task ID
+// is derived from the period, so running the fan-out twice in the same hour sends one email.
+func (s *reportService) EnqueueDue(ctx context.Context, now time.Time) error {
+	users, err := s.userRepo.ListReportSubscribers(ctx, s.db)
+	if err != nil {
+		return fmt.Errorf("failed to list report subscribers: %w", err)
+	}
+
+	queued := 0
+	for _, user := range users {
+		loc, err := time.LoadLocation(user.Timezone)
+		if err != nil {
+			loc, _ = time.LoadLocation(utils.DefaultTimezone)
+		}
+
+		from, to, ok := reportPeriod(user.ReportSchedule, now, loc)
+		if !ok {
+			continue
+		}
+
+		payload, err := json.Marshal(ReportPayload{UserID: user.ID, Schedule: user.ReportSchedule, From: from, To: to})
+		if err != nil {
+			return fmt.Errorf("failed to marshal report payload: %w", err)
+		}
+
+		taskID := fmt.Sprintf("report:%d:%s:%s", user.ID, user.ReportSchedule, from.Format("20060102"))
+		_, err = tasks.Enqueue(ctx, s.asyncClient, tasks.TaskSendReport, payload, asynq.TaskID(taskID), asynq.MaxRetry(3), asynq.Retention(24*time.Hour))
+		if errors.Is(err, asynq.ErrTaskIDConflict) {
+			continue
+		}
+		if err != nil {
+			utils.Error(ctx, "Failed to enqueue report", map[string]any{"user_id": user.ID, "error": err.Error()})
+			continue
+		}
+		queued++
+	}
+
+	utils.Info(ctx, "Scheduled reports queued", map[string]any{"subscribers": len(users), "queued": queued})
+	return nil
+}
+
+// Deliver builds a scheduled report and emails it to the user.
+func (s *reportService) Deliver(ctx context.Context, payload *ReportPayload, appUrl string) error {
+	user, err := s.userRepo.FindByID(ctx, s.db, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user.ReportSchedule != payload.Schedule {
+		utils.Info(ctx, "Report schedule changed, skipping report", map[string]any{"user_id": user.ID})
+		return nil
+	}
+
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		loc, _ = time.LoadLocation(utils.DefaultTimezone)
+	}
+
+	urls, err := s.urlRepo.ListAllByUserID(ctx, s.db, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list urls: %w", err)
+	}
+	if len(urls) == 0 {
+		utils.Info(ctx, "User has no monitors, skipping report", map[string]any{"user_id": user.ID})
+		return nil
+	}
+
+	// The period is decoded with a fixed offset; calendar math across a DST change needs the zone.
+	from, to := payload.From.In(loc), payload.To.In(loc)
+
+	now := time.Now()
+	current, monitors, err := s.reliability(ctx, urls, from, to, now)
+	if err != nil {
+		return fmt.Errorf("failed to build report: %w", err)
+	}
+	prevFrom, prevTo := previousPeriod(payload.Schedule, from)
+	previous, _, err := s.reliability(ctx, urls, prevFrom, prevTo, now)
+	if err != nil {
+		return fmt.Errorf("failed to build previous report: %w", err)
+	}
+
+	sort.SliceStable(monitors, func(i, j int) bool { return monitors[i].UptimePercent < monitors[j].UptimePercent })
+	monitorRows := make([]map[string]any, 0, len(monitors))
+	for _, monitor := range monitors {
+		monitorRows = append(monitorRows, map[string]any{
+			"Label":     monitor.Label,
+			"Uptime":    fmt.Sprintf("%.2f%%", monitor.UptimePercent),
+			"Incidents": monitor.IncidentCount,
+			"Downtime":  formatSeconds(monitor.DowntimeSeconds),
+		})
+	}
+
+	slowest := append([]MonitorReliability{}, monitors...)
+	sort.SliceStable(slowest, func(i, j int) bool { return slowest[i].AvgResponseTime > slowest[j].AvgResponseTime })
+	slowestRows := []map[string]any{}
+	for _, monitor := range slowest {
+		if len(slowestRows) == reportSlowest || monitor.TotalChecks == 0 {
+			break
+		}
+		slowestRows = append(slowestRows, map[string]any{
+			"Label":           monitor.Label,
+			"AvgResponseTime": fmt.Sprintf("%.0f ms", monitor.AvgResponseTime),
+		})
+	}
+
+	mttr := "-"
+	if current.MTTRSeconds != nil {
+		mttr = formatSeconds(*current.MTTRSeconds)
+	}
+
+	// The numbers matter more than the pictures, so a chart failure still sends the report.
+	images, err := s.reportCharts(ctx, urls, from, to, loc)
+	if err != nil {
+		utils.Warn(ctx, "Failed to render report charts", map[string]any{"user_id": user.ID, "error": err.Error()})
+	}
+
+	subject := fmt.Sprintf("Laporan Uptime %s", reportLabels[payload.Schedule])
+	err = tasks.EnqueueEmail(ctx, s.asyncClient, user.Email, subject, email.EmailUptimeReport, map[string]any{
+		"LogoURL":        fmt.Sprintf("%s/icon.png", appUrl),
+		"Name":           user.Name,
+		"Period":         reportLabels[payload.Schedule],
+		"From":           from.Format("02 Jan 2006"),
+		"To":             to.Add(-time.Second).Format("02 Jan 2006"),
+		"Uptime":         fmt.Sprintf("%.2f%%", current.UptimePercent),
+		"UptimeChange":   fmt.Sprintf("%+.2f%%", current.UptimePercent-previous.UptimePercent),
+		"Incidents":      current.IncidentCount,
+		"IncidentChange": fmt.Sprintf("%+d", current.IncidentCount-previous.IncidentCount),
+		"Downtime":       formatSeconds(current.DowntimeSeconds),
+		"MTTR":           mttr,
+		"Monitors":       monitorRows,
+		"Slowest":        slowestRows,
+		"Charts":         len(images) > 0,
+		"DashboardLink":  appUrl,
+	}, images...)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue report email: %w", err)
+	}
+
+	utils.Info(ctx, "Report delivered", map[string]any{"user_id": user.ID, "schedule": payload.Schedule, "from": payload.From})
+	return nil
+}
+
+// reportCharts renders daily charts for the whole account over [from, to), merging every monitor
+// into one figure per day and weighting response times by the number of checks.
+func (s *reportService) reportCharts(ctx context.Context, urls []models.URL, from, to time.Time, loc *time.Location) ([]email.InlineImage, error) {
+	urlIDs := make([]uint, 0, len(urls))
+	for _, u := range urls {
+		urlIDs = append(urlIDs, u.ID)
+	}
+
+	from, to = from.In(loc), to.In(loc)
+	stats, err := s.logRepo.GetDailyUptimeByURLIDs(ctx, s.db, urlIDs, loc.String(), from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily uptime: %w", err)
+	}
+
+	days := map[int64]*models.UptimeStat{}
+	responseTimeSums := map[int64]float64{}
+	responseTimeChecks := map[int64]int{}
+	for _, stat := range stats {
+		key := stat.BucketStart.Unix()
+		day, ok := days[key]
+		if !ok {
+			day = &models.UptimeStat{BucketStart: stat.BucketStart}
+			days[key] = day
+		}
+		day.TotalChecks += stat.TotalChecks
+		day.UpChecks += stat.UpChecks
+		day.MaintenanceChecks += stat.MaintenanceChecks
+		if stat.AvgResponseTime != nil {
+			responseTimeSums[key] += *stat.AvgResponseTime * float64(stat.TotalChecks)
+			responseTimeChecks[key] += stat.TotalChecks
+		}
+	}
+
+	starts := []time.Time{}
+	merged := []models.UptimeStat{}
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		starts = append(starts, day)
+		stat, ok := days[day.Unix()]
+		if !ok {
+			continue
+		}
+		if eligible := stat.TotalChecks - stat.MaintenanceChecks; eligible > 0 {
+			stat.UptimePercent = float64(stat.UpChecks) * 100 / float64(eligible)
+		}
+		if checks := responseTimeChecks[day.Unix()]; checks > 0 {
+			avg := responseTimeSums[day.Unix()] / float64(checks)
+			stat.AvgResponseTime = &avg
+		}
+		merged = append(merged, *stat)
+	}
+
+	return tasks.RenderCharts(starts, merged)
+}
+
+func formatSeconds(seconds int64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
@@ -0,0 +1,111 @@
+package report
+
+import (
+	"testing"
+	"time"
+	"uptimatic/internal/models"
+)
+
+func TestReportPeriod(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load America/New_York: %v", err)
+	}
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("failed to load Asia/Jakarta: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		schedule string
+		now      time.Time
+		loc      *time.Location
+		wantFrom time.Time
+		wantTo   time.Time
+		wantOK   bool
+	}{
+		{
+			name:     "weekly on monday morning",
+			schedule: models.ReportScheduleWeekly,
+			now:      time.Date(2026, 3, 16, 8, 15, 0, 0, ny),
+			loc:      ny,
+			wantFrom: time.Date(2026, 3, 9, 0, 0, 0, 0, ny),
+			wantTo:   time.Date(2026, 3, 16, 0, 0, 0, 0, ny),
+			wantOK:   true,
+		},
+		{
+			// The week of the spring forward is an hour short, but still starts at local midnight.
+			name:     "weekly across spring forward",
+			schedule: models.ReportScheduleWeekly,
+			now:      time.Date(2026, 3, 9, 8, 0, 0, 0, ny),
+			loc:      ny,
+			wantFrom: time.Date(2026, 3, 2, 0, 0, 0, 0, ny),
+			wantTo:   time.Date(2026, 3, 9, 0, 0, 0, 0, ny),
+			wantOK:   true,
+		},
+		{
+			name:     "weekly uses the local day",
+			schedule: models.ReportScheduleWeekly,
+			now:      time.Date(2026, 3, 16, 1, 0, 0, 0, time.UTC),
+			loc:      jakarta,
+			wantFrom: time.Date(2026, 3, 9, 0, 0, 0, 0, jakarta),
+			wantTo:   time.Date(2026, 3, 16, 0, 0, 0, 0, jakarta),
+			wantOK:   true,
+		},
+		{
+			name:     "weekly at another hour",
+			schedule: models.ReportScheduleWeekly,
+			now:      time.Date(2026, 3, 16, 9, 0, 0, 0, ny),
+			loc:      ny,
+		},
+		{
+			name:     "weekly on another day",
+			schedule: models.ReportScheduleWeekly,
+			now:      time.Date(2026, 3, 17, 8, 0, 0, 0, ny),
+			loc:      ny,
+		},
+		{
+			name:     "monthly on the first",
+			schedule: models.ReportScheduleMonthly,
+			now:      time.Date(2026, 4, 1, 8, 30, 0, 0, ny),
+			loc:      ny,
+			wantFrom: time.Date(2026, 3, 1, 0, 0, 0, 0, ny),
+			wantTo:   time.Date(2026, 4, 1, 0, 0, 0, 0, ny),
+			wantOK:   true,
+		},
+		{
+			name:     "monthly across the year",
+			schedule: models.ReportScheduleMonthly,
+			now:      time.Date(2026, 1, 1, 8, 0, 0, 0, jakarta),
+			loc:      jakarta,
+			wantFrom: time.Date(2025, 12, 1, 0, 0, 0, 0, jakarta),
+			wantTo:   time.Date(2026, 1, 1, 0, 0, 0, 0, jakarta),
+			wantOK:   true,
+		},
+		{
+			name:     "monthly on a monday that is not the first",
+			schedule: models.ReportScheduleMonthly,
+			now:      time.Date(2026, 3, 16, 8, 0, 0, 0, ny),
+			loc:      ny,
+		},
+		{
+			name:     "no schedule",
+			schedule: "",
+			now:      time.Date(2026, 6, 1, 8, 0, 0, 0, ny),
+			loc:      ny,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, ok := reportPeriod(tt.schedule, tt.now, tt.loc)
+			if ok != tt.wantOK {
+				t.Fatalf("reportPeriod() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("reportPeriod() = [%v, %v), want [%v, %v)", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package report
+
+import (
+	"uptimatic/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler interface {
+	ReliabilityHandler(c *gin.Context)
+}
+
+type reportHandler struct {
+	reportService ReportService
+}
+
+func NewReportHandler(reportService ReportService) ReportHandler {
+	return &reportHandler{reportService}
+}
+
+func (h *reportHandler) ReliabilityHandler(c *gin.Context) {
+	query := ReliabilityQuery{
+		Month: c.Query("month"),
+		From:  c.Query("from"),
+		To:    c.Query("to"),
+		Sort:  c.Query("sort"),
+		Order: c.Query("order"),
+	}
+
+	report, err := h.reportService.GetReliability(c.Request.Context(), c.GetUint("user_id"), &query)
+	if err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, report)
+}
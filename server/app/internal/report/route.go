@@ -0,0 +1,17 @@
+package report
+
+import (
+	"uptimatic/internal/middleware"
+	"uptimatic/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ReportRoutes(r *gin.RouterGroup, h ReportHandler, jwtUtil *utils.JWTUtil) {
+	reports := r.Group("/reports")
+	reports.Use(middleware.AuthMiddleware(jwtUtil))
+	reports.Use(middleware.VerifiedMiddleware())
+	{
+		reports.GET("/reliability", h.ReliabilityHandler)
+	}
+}
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReliabilityQuery struct {
+	Month string
+	From  string
+	To    string
+	Sort  string
+	Order string
+}
+
+// Reliability holds the figures reported per monitor and for the whole account. Durations are in
+// seconds; MTTR and MTBF are null when there was no incident in the period.
+type Reliability struct {
+	IncidentCount        int     `json:"incident_count"`
+	DowntimeSeconds      int64   `json:"downtime_seconds"`
+	MTTRSeconds          *int64  `json:"mttr_seconds"`
+	MTBFSeconds          *int64  `json:"mtbf_seconds"`
+	LongestOutageSeconds int64   `json:"longest_outage_seconds"`
+	TotalChecks          int     `json:"total_checks"`
+	UptimePercent        float64 `json:"uptime_percent"`
+	AvgResponseTime      float64 `json:"avg_response_time"`
+}
+
+type MonitorReliability struct {
+	ID    uuid.UUID `json:"id"`
+	Label string    `json:"label"`
+	URL   string    `json:"url"`
+	Reliability
+}
+
+type AccountReliability struct {
+	MonitorCount int `json:"monitor_count"`
+	Reliability
+}
+
+type ReliabilityResponse struct {
+	From     time.Time            `json:"from"`
+	To       time.Time            `json:"to"`
+	Timezone string               `json:"timezone"`
+	Sort     string               `json:"sort"`
+	Order    string               `json:"order"`
+	Account  AccountReliability   `json:"account"`
+	Monitors []MonitorReliability `json:"monitors"`
+}
+
+// ReportPayload asks for one scheduled report email covering [From, To).
+type ReportPayload struct {
+	UserID   uint      `json:"user_id"`
+	Schedule string    `json:"schedule"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+}
@@ -0,0 +1,278 @@
+package report
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+	"uptimatic/internal/models"
+	"uptimatic/internal/url"
+	"uptimatic/internal/user"
+	"uptimatic/internal/utils"
+
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+)
+
+const maxReportRange = 366 * 24 * time.Hour
+
+// reliabilitySorts maps the sort flag to its comparison and default order, which puts the worst
+// monitors first.
+var reliabilitySorts = map[string]struct {
+	Less  func(a, b *MonitorReliability) bool
+	Order string
+}{
+	"uptime":         {func(a, b *MonitorReliability) bool { return a.UptimePercent < b.UptimePercent }, "asc"},
+	"downtime":       {func(a, b *MonitorReliability) bool { return a.DowntimeSeconds < b.DowntimeSeconds }, "desc"},
+	"incidents":      {func(a, b *MonitorReliability) bool { return a.IncidentCount < b.IncidentCount }, "desc"},
+	"mttr":           {func(a, b *MonitorReliability) bool { return orZero(a.MTTRSeconds) < orZero(b.MTTRSeconds) }, "desc"},
+	"mtbf":           {func(a, b *MonitorReliability) bool { return orMax(a.MTBFSeconds) < orMax(b.MTBFSeconds) }, "asc"},
+	"longest_outage": {func(a, b *MonitorReliability) bool { return a.LongestOutageSeconds < b.LongestOutageSeconds }, "desc"},
+	"label":          {func(a, b *MonitorReliability) bool { return a.Label < b.Label }, "asc"},
+}
+
+type ReportService interface {
+	GetReliability(ctx context.Context, userID uint, query *ReliabilityQuery) (*ReliabilityResponse, *utils.AppError)
+	EnqueueDue(ctx context.Context, now time.Time) error
+	Deliver(ctx context.Context, payload *ReportPayload, appUrl string) error
+}
+
+type reportService struct {
+	db           *gorm.DB
+	asyncClient  *asynq.Client
+	urlRepo      url.UrlRepository
+	logRepo      url.StatusLogRepository
+	incidentRepo url.IncidentRepository
+	userRepo     user.UserRepository
+}
+
+func NewReportService(db *gorm.DB, asyncClient *asynq.Client, urlRepo url.UrlRepository, logRepo url.StatusLogRepository, incidentRepo url.IncidentRepository, userRepo user.UserRepository) ReportService {
+	return &reportService{db, asyncClient, urlRepo, logRepo, incidentRepo, userRepo}
+}
+
+func (s *reportService) GetReliability(ctx context.Context, userID uint, query *ReliabilityQuery) (*ReliabilityResponse, *utils.AppError) {
+	sortBy := query.Sort
+	if sortBy == "" {
+		sortBy = "uptime"
+	}
+	sorter, ok := reliabilitySorts[sortBy]
+	if !ok {
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid sort", nil)
+	}
+	order := query.Order
+	if order == "" {
+		order = sorter.Order
+	}
+	if order != "asc" && order != "desc" {
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid order", nil)
+	}
+
+	timezone, err := s.urlRepo.GetOwnerTimezone(ctx, s.db, userID)
+	if err != nil {
+		utils.Error(ctx, "Failed to get user timezone", map[string]any{"user_id": userID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading timezone", err)
+	}
+	if timezone == "" {
+		timezone = utils.DefaultTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc, _ = time.LoadLocation(utils.DefaultTimezone)
+	}
+
+	start, end, appErr := reportRange(query, time.Now(), loc)
+	if appErr != nil {
+		utils.Warn(ctx, "Invalid report range", map[string]any{"user_id": userID, "err": appErr.Message})
+		return nil, appErr
+	}
+
+	urls, err := s.urlRepo.ListAllByUserID(ctx, s.db, userID)
+	if err != nil {
+		utils.Error(ctx, "Failed to list URLs", map[string]any{"user_id": userID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error listing urls", err)
+	}
+
+	account, monitors, err := s.reliability(ctx, urls, start, end, time.Now())
+	if err != nil {
+		utils.Error(ctx, "Failed to build reliability report", map[string]any{"user_id": userID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error building report", err)
+	}
+
+	sort.SliceStable(monitors, func(i, j int) bool {
+		if order == "desc" {
+			return sorter.Less(&monitors[j], &monitors[i])
+		}
+		return sorter.Less(&monitors[i], &monitors[j])
+	})
+
+	utils.Info(ctx, "Reliability report built", map[string]any{"user_id": userID, "from": start, "to": end, "monitors": len(monitors)})
+	return &ReliabilityResponse{
+		From:     start.In(loc),
+		To:       end.In(loc),
+		Timezone: loc.String(),
+		Sort:     sortBy,
+		Order:    order,
+		Account:  account,
+		Monitors: monitors,
+	}, nil
+}
+
+// reliability computes the report figures for each monitor and the whole account over [start, end).
+// Time after now or before a monitor was created does not count towards MTBF.
+func (s *reportService) reliability(ctx context.Context, urls []models.URL, start, end, now time.Time) (AccountReliability, []MonitorReliability, error) {
+	if end.After(now) {
+		end = now
+	}
+
+	urlIDs := make([]uint, 0, len(urls))
+	for _, u := range urls {
+		urlIDs = append(urlIDs, u.ID)
+	}
+
+	uptimes, err := s.logRepo.GetUptimeSummaryByURLIDs(ctx, s.db, urlIDs, start.UTC(), end.UTC())
+	if err != nil {
+		return AccountReliability{}, nil, err
+	}
+	uptimeByURL := map[uint]models.UptimeSummary{}
+	for _, uptime := range uptimes {
+		uptimeByURL[uptime.URLID] = uptime.UptimeSummary
+	}
+
+	incidents, err := s.incidentRepo.SummarizeByURLIDs(ctx, s.db, urlIDs, start.UTC(), end.UTC())
+	if err != nil {
+		return AccountReliability{}, nil, err
+	}
+	incidentsByURL := map[uint]models.IncidentSummary{}
+	for _, incident := range incidents {
+		incidentsByURL[incident.URLID] = incident
+	}
+
+	var incidentCount, upChecks, eligibleChecks, totalChecks int
+	var downtime, longest int64
+	var responseTimeSum float64
+	var observed time.Duration
+	monitors := make([]MonitorReliability, 0, len(urls))
+	for _, u := range urls {
+		from := start
+		if u.CreatedAt.After(from) {
+			from = u.CreatedAt
+		}
+		monitorObserved := max(end.Sub(from), 0)
+
+		uptime := uptimeByURL[u.ID]
+		incident := incidentsByURL[u.ID]
+		monitor := MonitorReliability{
+			ID:          u.PublicID,
+			Label:       u.Label,
+			URL:         u.URL,
+			Reliability: newReliability(incident.IncidentCount, incident.DowntimeSeconds, incident.LongestOutageSeconds, monitorObserved),
+		}
+		monitor.TotalChecks = uptime.TotalChecks
+		monitor.UptimePercent = 100
+		if uptime.TotalChecks > 0 {
+			monitor.UptimePercent = uptime.UptimePercent
+		}
+		monitor.AvgResponseTime = math.Round(uptime.AvgResponseTime*100) / 100
+		monitors = append(monitors, monitor)
+
+		incidentCount += incident.IncidentCount
+		downtime += incident.DowntimeSeconds
+		longest = max(longest, incident.LongestOutageSeconds)
+		observed += monitorObserved
+		totalChecks += uptime.TotalChecks
+		upChecks += uptime.UpChecks
+		eligibleChecks += uptime.TotalChecks - uptime.MaintenanceChecks
+		responseTimeSum += uptime.AvgResponseTime * float64(uptime.TotalChecks-uptime.MaintenanceChecks)
+	}
+
+	account := AccountReliability{
+		MonitorCount: len(urls),
+		Reliability:  newReliability(incidentCount, downtime, longest, observed),
+	}
+	account.TotalChecks = totalChecks
+	account.UptimePercent = 100
+	if eligibleChecks > 0 {
+		account.UptimePercent = math.Round(float64(upChecks)*100/float64(eligibleChecks)*100) / 100
+		account.AvgResponseTime = math.Round(responseTimeSum/float64(eligibleChecks)*100) / 100
+	}
+
+	return account, monitors, nil
+}
+
+// newReliability derives MTTR and MTBF: the mean outage length, and the mean time spent up
+// between outages over the observed period.
+func newReliability(incidents int, downtimeSeconds, longestSeconds int64, observed time.Duration) Reliability {
+	reliability := Reliability{
+		IncidentCount:        incidents,
+		DowntimeSeconds:      downtimeSeconds,
+		LongestOutageSeconds: longestSeconds,
+	}
+	if incidents == 0 {
+		return reliability
+	}
+
+	mttr := downtimeSeconds / int64(incidents)
+	mtbf := max(int64(observed.Seconds())-downtimeSeconds, 0) / int64(incidents)
+	reliability.MTTRSeconds = &mttr
+	reliability.MTBFSeconds = &mtbf
+	return reliability
+}
+
+// reportRange resolves the reported period: a calendar month in the user's time zone, an explicit
+// from/to range, or by default the current month so far.
+func reportRange(query *ReliabilityQuery, now time.Time, loc *time.Location) (time.Time, time.Time, *utils.AppError) {
+	var start, end time.Time
+
+	switch {
+	case query.Month != "":
+		month, err := time.ParseInLocation("2006-01", query.Month, loc)
+		if err != nil {
+			return start, end, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid month (use YYYY-MM)", err)
+		}
+		start, end = month, month.AddDate(0, 1, 0)
+	case query.From != "":
+		var err error
+		start, err = time.Parse(time.RFC3339, query.From)
+		if err != nil {
+			return start, end, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid from (use RFC3339)", err)
+		}
+		end = now
+		if query.To != "" {
+			end, err = time.Parse(time.RFC3339, query.To)
+			if err != nil {
+				return start, end, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid to (use RFC3339)", err)
+			}
+		}
+	case query.To != "":
+		return start, end, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "from is required when to is set", nil)
+	default:
+		local := now.In(loc)
+		start, end = time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc), now
+	}
+
+	if !end.After(start) {
+		return start, end, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "to must be after from", nil)
+	}
+	if !start.Before(now) {
+		return start, end, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Period must start in the past", nil)
+	}
+	if end.Sub(start) > maxReportRange {
+		return start, end, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Period is longer than a year", nil)
+	}
+	return start, end, nil
+}
+
+func orZero(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func orMax(v *int64) int64 {
+	if v == nil {
+		return math.MaxInt64
+	}
+	return *v
+}
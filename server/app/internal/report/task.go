@@ -0,0 +1,47 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"uptimatic/internal/config"
+	"uptimatic/internal/utils"
+
+	"github.com/hibiken/asynq"
+)
+
+type TaskHandler struct {
+	cfg           *config.Config
+	reportService ReportService
+}
+
+func NewTaskHandler(cfg *config.Config, reportService ReportService) *TaskHandler {
+	return &TaskHandler{cfg, reportService}
+}
+
+// ScheduleReportsHandler runs hourly and queues the reports that are due in each subscriber's time zone.
+func (h *TaskHandler) ScheduleReportsHandler(ctx context.Context, t *asynq.Task) error {
+	if err := h.reportService.EnqueueDue(ctx, time.Now()); err != nil {
+		utils.Error(ctx, "Failed to schedule reports", map[string]any{"error": err.Error()})
+		return err
+	}
+	return nil
+}
+
+func (h *TaskHandler) SendReportHandler(ctx context.Context, t *asynq.Task) error {
+	var payload ReportPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		utils.Error(ctx, "Failed to unmarshal report payload", map[string]any{"error": err.Error()})
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	utils.Info(ctx, "Building report", map[string]any{"user_id": payload.UserID, "schedule": payload.Schedule, "from": payload.From, "to": payload.To})
+
+	appUrl := fmt.Sprintf("%s://%s", h.cfg.AppScheme, h.cfg.AppDomain)
+	if err := h.reportService.Deliver(ctx, &payload, appUrl); err != nil {
+		utils.Error(ctx, "Failed to deliver report", map[string]any{"user_id": payload.UserID, "error": err.Error()})
+		return err
+	}
+	return nil
+}
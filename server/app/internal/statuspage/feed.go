@@ -0,0 +1,609 @@
+package statuspage
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"uptimatic/internal/models"
+	"uptimatic/internal/url"
+	"uptimatic/internal/utils"
+)
+
+const (
+	FeedRSS  = "rss"
+	FeedAtom = "atom"
+)
+
+const feedIncidentLimit = 50
+
+// maintenanceLookahead is how far ahead scheduled maintenances are listed in the v2 summary.
+const maintenanceLookahead = 7 * 24 * time.Hour
+
+// feedEntry is one item of the RSS and Atom feeds, built from an announcement or a monitor incident.
+type feedEntry struct {
+	ID        string
+	Title     string
+	Body      string
+	Published time.Time
+	Updated   time.Time
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	PubDate     string  `xml:"pubDate"`
+	GUID        rssGUID `xml:"guid"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Link      atomLink    `xml:"link"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// statuspageIndicators maps our overall page status to the Statuspage v2 indicator and description.
+var statuspageIndicators = map[string]V2Status{
+	StatusOperational:      {Indicator: "none", Description: "All Systems Operational"},
+	StatusPartialOutage:    {Indicator: "major", Description: "Partial System Outage"},
+	StatusMajorOutage:      {Indicator: "critical", Description: "Major System Outage"},
+	StatusUnderMaintenance: {Indicator: "maintenance", Description: "Service Under Maintenance"},
+}
+
+// GetFeed renders the page's recent announcements and monitor incidents as an RSS 2.0 or Atom 1.0 document.
+func (s *statusPageService) GetFeed(ctx context.Context, slug, password, format, appUrl string) ([]byte, *utils.AppError) {
+	page, appErr := findAccessiblePage(ctx, s.db, s.pageRepo, slug, password)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	entries, appErr := s.feedEntries(ctx, page)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	link := statusPageLink(appUrl, page.Slug)
+	updated := page.CreatedAt
+	for _, entry := range entries {
+		if entry.Updated.After(updated) {
+			updated = entry.Updated
+		}
+	}
+
+	var doc any
+	switch format {
+	case FeedRSS:
+		feed := rssFeed{
+			Version: "2.0",
+			Channel: rssChannel{
+				Title:         fmt.Sprintf("%s status", page.Title),
+				Link:          link,
+				Description:   page.Description,
+				LastBuildDate: updated.UTC().Format(time.RFC1123Z),
+				Items:         []rssItem{},
+			},
+		}
+		for _, entry := range entries {
+			feed.Channel.Items = append(feed.Channel.Items, rssItem{
+				Title:       entry.Title,
+				Link:        link,
+				Description: entry.Body,
+				PubDate:     entry.Published.UTC().Format(time.RFC1123Z),
+				GUID:        rssGUID{Value: fmt.Sprintf("urn:uuid:%s", entry.ID)},
+			})
+		}
+		doc = feed
+	case FeedAtom:
+		feed := atomFeed{
+			ID:      fmt.Sprintf("urn:uuid:%s", page.PublicID),
+			Title:   fmt.Sprintf("%s status", page.Title),
+			Updated: updated.UTC().Format(time.RFC3339),
+			Link:    atomLink{Href: link, Rel: "alternate", Type: "text/html"},
+			Entries: []atomEntry{},
+		}
+		for _, entry := range entries {
+			feed.Entries = append(feed.Entries, atomEntry{
+				ID:        fmt.Sprintf("urn:uuid:%s", entry.ID),
+				Title:     entry.Title,
+				Published: entry.Published.UTC().Format(time.RFC3339),
+				Updated:   entry.Updated.UTC().Format(time.RFC3339),
+				Link:      atomLink{Href: link, Rel: "alternate", Type: "text/html"},
+				Content:   atomContent{Type: "html", Value: entry.Body},
+			})
+		}
+		doc = feed
+	default:
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Feed not found", nil)
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		utils.Error(ctx, "Failed to render status page feed", map[string]any{"slug": slug, "format": format, "err": err.Error()})
+		return nil, utils.InternalServerError("Error rendering feed", err)
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+func (s *statusPageService) GetV2Status(ctx context.Context, slug, password, appUrl string) (*V2StatusResponse, *utils.AppError) {
+	page, appErr := findAccessiblePage(ctx, s.db, s.pageRepo, slug, password)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	status, appErr := s.buildPublicStatus(ctx, page)
+	if appErr != nil {
+		return nil, appErr
+	}
+	loc, appErr := s.pageLocation(ctx, page)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &V2StatusResponse{
+		Page:   newV2Page(page, appUrl, loc, status.UpdatedAt),
+		Status: statuspageIndicators[status.Status],
+	}, nil
+}
+
+func (s *statusPageService) GetV2Summary(ctx context.Context, slug, password, appUrl string) (*V2SummaryResponse, *utils.AppError) {
+	page, appErr := findAccessiblePage(ctx, s.db, s.pageRepo, slug, password)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	status, appErr := s.buildPublicStatus(ctx, page)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	loc, appErr := s.pageLocation(ctx, page)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	unresolved, err := s.announcementRepo.ListUnresolvedByStatusPageID(ctx, s.db, page.ID)
+	if err != nil {
+		utils.Error(ctx, "Failed to load announcements", map[string]any{"slug": slug, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading status page", err)
+	}
+
+	urlIDs, componentsByURL := pageComponentsByURL(page)
+	open, err := s.incidentRepo.ListOpenByURLIDs(ctx, s.db, urlIDs)
+	if err != nil {
+		utils.Error(ctx, "Failed to load incidents", map[string]any{"slug": slug, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading status page", err)
+	}
+
+	windows, err := s.maintenanceRepo.ListActiveByURLIDs(ctx, s.db, urlIDs)
+	if err != nil {
+		utils.Error(ctx, "Failed to load maintenance windows", map[string]any{"slug": slug, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading status page", err)
+	}
+
+	components := []V2Component{}
+	statusByID := map[string]string{}
+	for i, component := range status.Components {
+		v2 := newV2Component(page, component.ID.String(), component.Name, i, component.Status, status.UpdatedAt)
+		statusByID[v2.ID] = v2.Status
+		components = append(components, v2)
+	}
+
+	resp := &V2SummaryResponse{
+		Page:                  newV2Page(page, appUrl, loc, status.UpdatedAt),
+		Components:            components,
+		Incidents:             []V2Incident{},
+		ScheduledMaintenances: []V2ScheduledMaintenance{},
+		Status:                statuspageIndicators[status.Status],
+	}
+	for i := range unresolved {
+		resp.Incidents = append(resp.Incidents, newV2Incident(page, &unresolved[i], appUrl, statusByID, status.UpdatedAt))
+	}
+	for i := range open {
+		component := componentsByURL[open[i].URLID]
+		resp.Incidents = append(resp.Incidents, newV2MonitorIncident(page, &open[i], &component, appUrl, statusByID, status.UpdatedAt))
+	}
+	sortV2Incidents(resp.Incidents)
+
+	for i := range windows {
+		start, end, ok := url.MaintenanceOccurrence(&windows[i], status.UpdatedAt, maintenanceLookahead)
+		if !ok {
+			continue
+		}
+		resp.ScheduledMaintenances = append(resp.ScheduledMaintenances,
+			newV2Maintenance(page, &windows[i], start, end, componentsByURL, appUrl, statusByID, status.UpdatedAt))
+	}
+	sort.SliceStable(resp.ScheduledMaintenances, func(i, j int) bool {
+		return resp.ScheduledMaintenances[i].ScheduledFor.Before(resp.ScheduledMaintenances[j].ScheduledFor)
+	})
+	return resp, nil
+}
+
+func (s *statusPageService) GetV2Incidents(ctx context.Context, slug, password, appUrl string) (*V2IncidentsResponse, *utils.AppError) {
+	page, appErr := findAccessiblePage(ctx, s.db, s.pageRepo, slug, password)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	announcements, appErr := s.recentAnnouncements(ctx, page)
+	if appErr != nil {
+		return nil, appErr
+	}
+	incidents, componentsByURL, appErr := s.recentMonitorIncidents(ctx, page)
+	if appErr != nil {
+		return nil, appErr
+	}
+	loc, appErr := s.pageLocation(ctx, page)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	now := time.Now().UTC()
+	resp := &V2IncidentsResponse{
+		Page:      newV2Page(page, appUrl, loc, now),
+		Incidents: []V2Incident{},
+	}
+	for i := range announcements {
+		resp.Incidents = append(resp.Incidents, newV2Incident(page, &announcements[i], appUrl, nil, now))
+	}
+	for i := range incidents {
+		component := componentsByURL[incidents[i].URLID]
+		resp.Incidents = append(resp.Incidents, newV2MonitorIncident(page, &incidents[i], &component, appUrl, nil, now))
+	}
+	sortV2Incidents(resp.Incidents)
+	if len(resp.Incidents) > feedIncidentLimit {
+		resp.Incidents = resp.Incidents[:feedIncidentLimit]
+	}
+	return resp, nil
+}
+
+func (s *statusPageService) recentAnnouncements(ctx context.Context, page *models.StatusPage) ([]models.StatusPageIncident, *utils.AppError) {
+	incidents, err := s.announcementRepo.ListRecentByStatusPageID(ctx, s.db, page.ID, feedIncidentLimit)
+	if err != nil {
+		utils.Error(ctx, "Failed to load announcements", map[string]any{"slug": page.Slug, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading status page", err)
+	}
+	return incidents, nil
+}
+
+// recentMonitorIncidents returns the latest automatic incidents of the page's monitors, with the
+// page components keyed by monitor.
+func (s *statusPageService) recentMonitorIncidents(ctx context.Context, page *models.StatusPage) ([]models.Incident, map[uint]models.StatusPageComponent, *utils.AppError) {
+	urlIDs, componentsByURL := pageComponentsByURL(page)
+	incidents, err := s.incidentRepo.ListRecentByURLIDs(ctx, s.db, urlIDs, feedIncidentLimit)
+	if err != nil {
+		utils.Error(ctx, "Failed to load incidents", map[string]any{"slug": page.Slug, "err": err.Error()})
+		return nil, nil, utils.InternalServerError("Error loading status page", err)
+	}
+	return incidents, componentsByURL, nil
+}
+
+// feedEntries merges announcements and monitor incidents into one feed, newest first.
+func (s *statusPageService) feedEntries(ctx context.Context, page *models.StatusPage) ([]feedEntry, *utils.AppError) {
+	announcements, appErr := s.recentAnnouncements(ctx, page)
+	if appErr != nil {
+		return nil, appErr
+	}
+	incidents, componentsByURL, appErr := s.recentMonitorIncidents(ctx, page)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	entries := make([]feedEntry, 0, len(announcements)+len(incidents))
+	for i := range announcements {
+		entries = append(entries, feedEntry{
+			ID:        announcements[i].PublicID.String(),
+			Title:     announcements[i].Title,
+			Body:      incidentFeedBody(&announcements[i]),
+			Published: announcements[i].CreatedAt,
+			Updated:   announcements[i].UpdatedAt,
+		})
+	}
+	for i := range incidents {
+		incident := &incidents[i]
+		name := componentsByURL[incident.URLID].Name
+		updated := incident.StartedAt
+		if incident.ResolvedAt != nil {
+			updated = *incident.ResolvedAt
+		}
+		entries = append(entries, feedEntry{
+			ID:        incident.PublicID.String(),
+			Title:     monitorIncidentTitle(name),
+			Body:      monitorIncidentFeedBody(incident, name),
+			Published: incident.StartedAt,
+			Updated:   updated,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Published.After(entries[j].Published) })
+	if len(entries) > feedIncidentLimit {
+		entries = entries[:feedIncidentLimit]
+	}
+	return entries, nil
+}
+
+func pageComponentsByURL(page *models.StatusPage) ([]uint, map[uint]models.StatusPageComponent) {
+	urlIDs := make([]uint, 0, len(page.Components))
+	componentsByURL := map[uint]models.StatusPageComponent{}
+	for _, component := range page.Components {
+		urlIDs = append(urlIDs, component.URLID)
+		componentsByURL[component.URLID] = component
+	}
+	return urlIDs, componentsByURL
+}
+
+func monitorIncidentTitle(component string) string {
+	return fmt.Sprintf("%s is down", component)
+}
+
+// monitorIncidentMessage describes how a monitor failed when its incident started.
+func monitorIncidentMessage(incident *models.Incident, component string) string {
+	if incident.StatusCode == url.StatusNoResponse {
+		return fmt.Sprintf("%s is not responding.", component)
+	}
+	return fmt.Sprintf("%s is responding with HTTP %d.", component, incident.StatusCode)
+}
+
+// monitorIncidentFeedBody renders an automatic incident like incidentFeedBody renders announcements.
+func monitorIncidentFeedBody(incident *models.Incident, component string) string {
+	var b strings.Builder
+	if incident.ResolvedAt != nil {
+		fmt.Fprintf(&b, "<p><small>%s</small><br><strong>%s</strong> - %s</p>",
+			incident.ResolvedAt.UTC().Format("Jan 2, 15:04 MST"),
+			html.EscapeString(incidentStatusLabels[models.IncidentResolved]),
+			html.EscapeString(fmt.Sprintf("%s is back up.", component)),
+		)
+	}
+	fmt.Fprintf(&b, "<p><small>%s</small><br><strong>%s</strong> - %s</p>",
+		incident.StartedAt.UTC().Format("Jan 2, 15:04 MST"),
+		html.EscapeString(incidentStatusLabels[models.IncidentInvestigating]),
+		html.EscapeString(monitorIncidentMessage(incident, component)),
+	)
+	fmt.Fprintf(&b, "<p>This incident affected: %s.</p>", html.EscapeString(component))
+	return b.String()
+}
+
+// incidentFeedBody lists every update of an incident, newest first, as a small HTML fragment.
+func incidentFeedBody(incident *models.StatusPageIncident) string {
+	var b strings.Builder
+	for _, update := range incident.Updates {
+		fmt.Fprintf(&b, "<p><small>%s</small><br><strong>%s</strong> - %s</p>",
+			update.CreatedAt.UTC().Format("Jan 2, 15:04 MST"),
+			html.EscapeString(incidentStatusLabels[update.Status]),
+			html.EscapeString(update.Message),
+		)
+	}
+	if len(incident.Components) > 0 {
+		names := make([]string, 0, len(incident.Components))
+		for _, component := range incident.Components {
+			names = append(names, html.EscapeString(component.Name))
+		}
+		fmt.Fprintf(&b, "<p>This incident affected: %s.</p>", strings.Join(names, ", "))
+	}
+	return b.String()
+}
+
+func newV2Page(page *models.StatusPage, appUrl string, loc *time.Location, updatedAt time.Time) V2Page {
+	return V2Page{
+		ID:        page.PublicID.String(),
+		Name:      page.Title,
+		URL:       statusPageLink(appUrl, page.Slug),
+		TimeZone:  loc.String(),
+		UpdatedAt: updatedAt,
+	}
+}
+
+func newV2Component(page *models.StatusPage, id, name string, position int, status string, updatedAt time.Time) V2Component {
+	// Statuspage has no "unknown" component state; a monitor without checks yet is reported as operational.
+	if status == StatusUnknown {
+		status = StatusOperational
+	}
+	return V2Component{
+		ID:        id,
+		Name:      name,
+		Status:    status,
+		Position:  position + 1,
+		PageID:    page.PublicID.String(),
+		CreatedAt: page.CreatedAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+func newV2Incident(page *models.StatusPage, incident *models.StatusPageIncident, appUrl string, statusByID map[string]string, updatedAt time.Time) V2Incident {
+	components := []V2Component{}
+	for _, component := range incident.Components {
+		status := statusByID[component.PublicID.String()]
+		if status == "" {
+			status = StatusOperational
+		}
+		components = append(components, newV2Component(page, component.PublicID.String(), component.Name, component.Position, status, updatedAt))
+	}
+
+	updates := []V2IncidentUpdate{}
+	var monitoringAt *time.Time
+	for _, update := range incident.Updates {
+		updates = append(updates, V2IncidentUpdate{
+			ID:         update.PublicID.String(),
+			Status:     update.Status,
+			Body:       update.Message,
+			IncidentID: incident.PublicID.String(),
+			DisplayAt:  update.CreatedAt,
+			CreatedAt:  update.CreatedAt,
+			UpdatedAt:  update.CreatedAt,
+		})
+		if update.Status == models.IncidentMonitoring {
+			createdAt := update.CreatedAt
+			monitoringAt = &createdAt
+		}
+	}
+
+	impact := "minor"
+	if incident.Status == models.IncidentResolved {
+		impact = "none"
+	}
+
+	return V2Incident{
+		ID:              incident.PublicID.String(),
+		Name:            incident.Title,
+		Status:          incident.Status,
+		Impact:          impact,
+		Shortlink:       statusPageLink(appUrl, page.Slug),
+		PageID:          page.PublicID.String(),
+		IncidentUpdates: updates,
+		Components:      components,
+		MonitoringAt:    monitoringAt,
+		ResolvedAt:      incident.ResolvedAt,
+		StartedAt:       incident.CreatedAt,
+		CreatedAt:       incident.CreatedAt,
+		UpdatedAt:       incident.UpdatedAt,
+	}
+}
+
+// newV2MonitorIncident reports an automatic incident of a page monitor as a Statuspage incident.
+func newV2MonitorIncident(page *models.StatusPage, incident *models.Incident, component *models.StatusPageComponent, appUrl string, statusByID map[string]string, updatedAt time.Time) V2Incident {
+	id := incident.PublicID.String()
+	status, impact := models.IncidentInvestigating, "major"
+	lastUpdate := incident.StartedAt
+
+	updates := []V2IncidentUpdate{}
+	if incident.ResolvedAt != nil {
+		status, impact, lastUpdate = models.IncidentResolved, "none", *incident.ResolvedAt
+		updates = append(updates, V2IncidentUpdate{
+			ID:         id + "-" + models.IncidentResolved,
+			Status:     models.IncidentResolved,
+			Body:       fmt.Sprintf("%s is back up.", component.Name),
+			IncidentID: id,
+			DisplayAt:  *incident.ResolvedAt,
+			CreatedAt:  *incident.ResolvedAt,
+			UpdatedAt:  *incident.ResolvedAt,
+		})
+	}
+	updates = append(updates, V2IncidentUpdate{
+		ID:         id + "-" + models.IncidentInvestigating,
+		Status:     models.IncidentInvestigating,
+		Body:       monitorIncidentMessage(incident, component.Name),
+		IncidentID: id,
+		DisplayAt:  incident.StartedAt,
+		CreatedAt:  incident.StartedAt,
+		UpdatedAt:  incident.StartedAt,
+	})
+
+	componentStatus := statusByID[component.PublicID.String()]
+	if componentStatus == "" {
+		componentStatus = StatusOperational
+	}
+
+	return V2Incident{
+		ID:              id,
+		Name:            monitorIncidentTitle(component.Name),
+		Status:          status,
+		Impact:          impact,
+		Shortlink:       statusPageLink(appUrl, page.Slug),
+		PageID:          page.PublicID.String(),
+		IncidentUpdates: updates,
+		Components:      []V2Component{newV2Component(page, component.PublicID.String(), component.Name, component.Position, componentStatus, updatedAt)},
+		ResolvedAt:      incident.ResolvedAt,
+		StartedAt:       incident.StartedAt,
+		CreatedAt:       incident.StartedAt,
+		UpdatedAt:       lastUpdate,
+	}
+}
+
+// newV2Maintenance reports the current or next occurrence [start, end) of a maintenance window.
+func newV2Maintenance(page *models.StatusPage, window *models.MaintenanceWindow, start, end time.Time, componentsByURL map[uint]models.StatusPageComponent, appUrl string, statusByID map[string]string, now time.Time) V2ScheduledMaintenance {
+	id := window.PublicID.String()
+	status := "scheduled"
+	if !start.After(now) {
+		status = "in_progress"
+	}
+
+	components := []V2Component{}
+	for _, u := range window.URLs {
+		component, ok := componentsByURL[u.ID]
+		if !ok {
+			continue
+		}
+		componentStatus := statusByID[component.PublicID.String()]
+		if componentStatus == "" {
+			componentStatus = StatusOperational
+		}
+		components = append(components, newV2Component(page, component.PublicID.String(), component.Name, component.Position, componentStatus, now))
+	}
+
+	return V2ScheduledMaintenance{
+		V2Incident: V2Incident{
+			ID:        id,
+			Name:      window.Title,
+			Status:    status,
+			Impact:    "maintenance",
+			Shortlink: statusPageLink(appUrl, page.Slug),
+			PageID:    page.PublicID.String(),
+			IncidentUpdates: []V2IncidentUpdate{{
+				ID:         id + "-" + status,
+				Status:     status,
+				Body:       window.Description,
+				IncidentID: id,
+				DisplayAt:  start,
+				CreatedAt:  window.CreatedAt,
+				UpdatedAt:  window.CreatedAt,
+			}},
+			Components: components,
+			StartedAt:  start,
+			CreatedAt:  window.CreatedAt,
+			UpdatedAt:  window.CreatedAt,
+		},
+		ScheduledFor:   start,
+		ScheduledUntil: end,
+	}
+}
+
+// sortV2Incidents orders incidents newest first, as Statuspage lists them.
+func sortV2Incidents(incidents []V2Incident) {
+	sort.SliceStable(incidents, func(i, j int) bool { return incidents[i].CreatedAt.After(incidents[j].CreatedAt) })
+}
@@ -0,0 +1,219 @@
+package statuspage
+
+import (
+	"fmt"
+	"net/http"
+	"uptimatic/internal/config"
+	"uptimatic/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const passwordHeader = "X-Status-Page-Password"
+
+type StatusPageHandler interface {
+	CreateHandler(c *gin.Context)
+	UpdateHandler(c *gin.Context)
+	DeleteHandler(c *gin.Context)
+	GetHandler(c *gin.Context)
+	ListHandler(c *gin.Context)
+	GetLogoUploadURLHandler(c *gin.Context)
+	ListPublicHandler(c *gin.Context)
+	GetPublicHandler(c *gin.Context)
+	RSSFeedHandler(c *gin.Context)
+	AtomFeedHandler(c *gin.Context)
+	V2StatusHandler(c *gin.Context)
+	V2SummaryHandler(c *gin.Context)
+	V2IncidentsHandler(c *gin.Context)
+}
+
+type statusPageHandler struct {
+	statusPageService StatusPageService
+	validate          *validator.Validate
+	cfg               *config.Config
+}
+
+func NewStatusPageHandler(statusPageService StatusPageService, validate *validator.Validate, cfg *config.Config) StatusPageHandler {
+	return &statusPageHandler{statusPageService, validate, cfg}
+}
+
+func (h *statusPageHandler) CreateHandler(c *gin.Context) {
+	var req StatusPageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid JSON payload", err))
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		utils.BindErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	resp, errSvc := h.statusPageService.Create(c.Request.Context(), &req, c.GetUint("user_id"))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *statusPageHandler) UpdateHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	var req StatusPageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid JSON payload", err))
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		utils.BindErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	resp, errSvc := h.statusPageService.Update(c.Request.Context(), &req, c.GetUint("user_id"), id)
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *statusPageHandler) DeleteHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	if errSvc := h.statusPageService.Delete(c.Request.Context(), c.GetUint("user_id"), id); errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, nil)
+}
+
+func (h *statusPageHandler) GetHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	resp, errSvc := h.statusPageService.FindByID(c.Request.Context(), c.GetUint("user_id"), id)
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *statusPageHandler) ListHandler(c *gin.Context) {
+	resp, errSvc := h.statusPageService.ListByUserID(c.Request.Context(), c.GetUint("user_id"))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *statusPageHandler) GetLogoUploadURLHandler(c *gin.Context) {
+	var req LogoUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid JSON payload", err))
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		utils.BindErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	url, fileName, errSvc := h.statusPageService.GetLogoUploadURL(c.Request.Context(), c.GetUint("user_id"), req.FileName, req.ContentType)
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, gin.H{
+		"file_name":     fileName,
+		"presigned_url": url,
+	})
+}
+
+func (h *statusPageHandler) ListPublicHandler(c *gin.Context) {
+	resp, errSvc := h.statusPageService.ListPublic(c.Request.Context())
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *statusPageHandler) GetPublicHandler(c *gin.Context) {
+	resp, errSvc := h.statusPageService.GetPublic(c.Request.Context(), c.Param("slug"), c.GetHeader(passwordHeader))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *statusPageHandler) RSSFeedHandler(c *gin.Context) {
+	h.feed(c, FeedRSS, "application/rss+xml; charset=utf-8")
+}
+
+func (h *statusPageHandler) AtomFeedHandler(c *gin.Context) {
+	h.feed(c, FeedAtom, "application/atom+xml; charset=utf-8")
+}
+
+func (h *statusPageHandler) feed(c *gin.Context, format, contentType string) {
+	feed, errSvc := h.statusPageService.GetFeed(c.Request.Context(), c.Param("slug"), c.GetHeader(passwordHeader), format, fmt.Sprintf("%s://%s", h.cfg.AppScheme, h.cfg.AppDomain))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, feed)
+}
+
+// The Statuspage v2 handlers write the bare document instead of the usual envelope so aggregators can parse it.
+func (h *statusPageHandler) V2StatusHandler(c *gin.Context) {
+	resp, errSvc := h.statusPageService.GetV2Status(c.Request.Context(), c.Param("slug"), c.GetHeader(passwordHeader), fmt.Sprintf("%s://%s", h.cfg.AppScheme, h.cfg.AppDomain))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *statusPageHandler) V2SummaryHandler(c *gin.Context) {
+	resp, errSvc := h.statusPageService.GetV2Summary(c.Request.Context(), c.Param("slug"), c.GetHeader(passwordHeader), fmt.Sprintf("%s://%s", h.cfg.AppScheme, h.cfg.AppDomain))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *statusPageHandler) V2IncidentsHandler(c *gin.Context) {
+	resp, errSvc := h.statusPageService.GetV2Incidents(c.Request.Context(), c.Param("slug"), c.GetHeader(passwordHeader), fmt.Sprintf("%s://%s", h.cfg.AppScheme, h.cfg.AppDomain))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,122 @@
+package statuspage
+
+import (
+	"fmt"
+	"net/http"
+	"uptimatic/internal/config"
+	"uptimatic/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type StatusIncidentHandler interface {
+	CreateHandler(c *gin.Context)
+	AddUpdateHandler(c *gin.Context)
+	DeleteHandler(c *gin.Context)
+	ListHandler(c *gin.Context)
+}
+
+type statusIncidentHandler struct {
+	incidentService StatusIncidentService
+	validate        *validator.Validate
+	cfg             *config.Config
+}
+
+func NewStatusIncidentHandler(incidentService StatusIncidentService, validate *validator.Validate, cfg *config.Config) StatusIncidentHandler {
+	return &statusIncidentHandler{incidentService, validate, cfg}
+}
+
+func (h *statusIncidentHandler) CreateHandler(c *gin.Context) {
+	pageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	var req StatusIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid JSON payload", err))
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		utils.BindErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	resp, errSvc := h.incidentService.Create(c.Request.Context(), &req, c.GetUint("user_id"), pageID, fmt.Sprintf("%s://%s", h.cfg.AppScheme, h.cfg.AppDomain))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *statusIncidentHandler) AddUpdateHandler(c *gin.Context) {
+	pageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+	incidentID, err := uuid.Parse(c.Param("incident_id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	var req StatusIncidentUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid JSON payload", err))
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		utils.BindErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	resp, errSvc := h.incidentService.AddUpdate(c.Request.Context(), &req, c.GetUint("user_id"), pageID, incidentID, fmt.Sprintf("%s://%s", h.cfg.AppScheme, h.cfg.AppDomain))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *statusIncidentHandler) DeleteHandler(c *gin.Context) {
+	pageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+	incidentID, err := uuid.Parse(c.Param("incident_id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	if errSvc := h.incidentService.Delete(c.Request.Context(), c.GetUint("user_id"), pageID, incidentID); errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, nil)
+}
+
+func (h *statusIncidentHandler) ListHandler(c *gin.Context) {
+	pageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	resp, errSvc := h.incidentService.ListByStatusPage(c.Request.Context(), c.GetUint("user_id"), pageID)
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
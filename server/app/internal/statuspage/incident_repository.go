@@ -0,0 +1,98 @@
+package statuspage
+
+import (
+	"context"
+	"time"
+	"uptimatic/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StatusIncidentRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, incident *models.StatusPageIncident) error
+	Update(ctx context.Context, tx *gorm.DB, incident *models.StatusPageIncident) error
+	Delete(ctx context.Context, tx *gorm.DB, incident *models.StatusPageIncident) error
+	AddUpdate(ctx context.Context, tx *gorm.DB, update *models.StatusPageIncidentUpdate) error
+	FindByPublicID(ctx context.Context, tx *gorm.DB, statusPageID uint, publicID uuid.UUID) (*models.StatusPageIncident, error)
+	ListByStatusPageID(ctx context.Context, tx *gorm.DB, statusPageID uint, since *time.Time) ([]models.StatusPageIncident, error)
+	ListUnresolvedByStatusPageID(ctx context.Context, tx *gorm.DB, statusPageID uint) ([]models.StatusPageIncident, error)
+	ListRecentByStatusPageID(ctx context.Context, tx *gorm.DB, statusPageID uint, limit int) ([]models.StatusPageIncident, error)
+}
+
+type statusIncidentRepository struct{}
+
+func NewStatusIncidentRepository() StatusIncidentRepository {
+	return &statusIncidentRepository{}
+}
+
+func preloadIncident(tx *gorm.DB) *gorm.DB {
+	return tx.
+		Preload("Components", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Updates", func(db *gorm.DB) *gorm.DB { return db.Order("created_at DESC, id DESC") })
+}
+
+func (r *statusIncidentRepository) Create(ctx context.Context, tx *gorm.DB, incident *models.StatusPageIncident) error {
+	return tx.WithContext(ctx).Omit("Components.*").Create(incident).Error
+}
+
+func (r *statusIncidentRepository) Update(ctx context.Context, tx *gorm.DB, incident *models.StatusPageIncident) error {
+	if err := tx.WithContext(ctx).Omit("Components", "Updates").Save(incident).Error; err != nil {
+		return err
+	}
+	return tx.WithContext(ctx).Model(incident).Association("Components").Replace(incident.Components)
+}
+
+func (r *statusIncidentRepository) Delete(ctx context.Context, tx *gorm.DB, incident *models.StatusPageIncident) error {
+	return tx.WithContext(ctx).Delete(incident).Error
+}
+
+func (r *statusIncidentRepository) AddUpdate(ctx context.Context, tx *gorm.DB, update *models.StatusPageIncidentUpdate) error {
+	return tx.WithContext(ctx).Create(update).Error
+}
+
+func (r *statusIncidentRepository) FindByPublicID(ctx context.Context, tx *gorm.DB, statusPageID uint, publicID uuid.UUID) (*models.StatusPageIncident, error) {
+	var incident models.StatusPageIncident
+	err := preloadIncident(tx.WithContext(ctx)).First(&incident, "public_id = ? AND status_page_id = ?", publicID, statusPageID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+func (r *statusIncidentRepository) ListByStatusPageID(ctx context.Context, tx *gorm.DB, statusPageID uint, since *time.Time) ([]models.StatusPageIncident, error) {
+	var incidents []models.StatusPageIncident
+	query := preloadIncident(tx.WithContext(ctx)).Where("status_page_id = ?", statusPageID)
+	if since != nil {
+		query = query.Where("created_at >= ? OR resolved_at IS NULL", *since)
+	}
+	if err := query.Order("created_at DESC").Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+func (r *statusIncidentRepository) ListUnresolvedByStatusPageID(ctx context.Context, tx *gorm.DB, statusPageID uint) ([]models.StatusPageIncident, error) {
+	var incidents []models.StatusPageIncident
+	err := preloadIncident(tx.WithContext(ctx)).
+		Where("status_page_id = ? AND resolved_at IS NULL", statusPageID).
+		Order("created_at DESC").
+		Find(&incidents).Error
+	if err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+func (r *statusIncidentRepository) ListRecentByStatusPageID(ctx context.Context, tx *gorm.DB, statusPageID uint, limit int) ([]models.StatusPageIncident, error) {
+	var incidents []models.StatusPageIncident
+	err := preloadIncident(tx.WithContext(ctx)).
+		Where("status_page_id = ?", statusPageID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&incidents).Error
+	if err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
@@ -0,0 +1,280 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"uptimatic/internal/adapters/email"
+	"uptimatic/internal/db"
+	"uptimatic/internal/models"
+	"uptimatic/internal/tasks"
+	"uptimatic/internal/url"
+	"uptimatic/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+)
+
+var incidentStatusLabels = map[string]string{
+	models.IncidentInvestigating: "Investigating",
+	models.IncidentIdentified:    "Identified",
+	models.IncidentMonitoring:    "Monitoring",
+	models.IncidentResolved:      "Resolved",
+}
+
+type StatusIncidentService interface {
+	Create(ctx context.Context, req *StatusIncidentRequest, userID uint, pageID uuid.UUID, appUrl string) (*StatusIncidentResponse, *utils.AppError)
+	AddUpdate(ctx context.Context, req *StatusIncidentUpdateRequest, userID uint, pageID, incidentID uuid.UUID, appUrl string) (*StatusIncidentResponse, *utils.AppError)
+	Delete(ctx context.Context, userID uint, pageID, incidentID uuid.UUID) *utils.AppError
+	ListByStatusPage(ctx context.Context, userID uint, pageID uuid.UUID) ([]StatusIncidentResponse, *utils.AppError)
+}
+
+type statusIncidentService struct {
+	db             *gorm.DB
+	pageRepo       StatusPageRepository
+	incidentRepo   StatusIncidentRepository
+	subscriberRepo SubscriberRepository
+	urlRepo        url.UrlRepository
+	asyncClient    *asynq.Client
+}
+
+func NewStatusIncidentService(db *gorm.DB, pageRepo StatusPageRepository, incidentRepo StatusIncidentRepository, subscriberRepo SubscriberRepository, urlRepo url.UrlRepository, asyncClient *asynq.Client) StatusIncidentService {
+	return &statusIncidentService{db, pageRepo, incidentRepo, subscriberRepo, urlRepo, asyncClient}
+}
+
+func (s *statusIncidentService) Create(ctx context.Context, req *StatusIncidentRequest, userID uint, pageID uuid.UUID, appUrl string) (*StatusIncidentResponse, *utils.AppError) {
+	utils.Info(ctx, "Creating status page incident", map[string]any{"status_page_id": pageID, "title": req.Title})
+
+	page, err := s.pageRepo.FindByPublicID(ctx, s.db, userID, pageID)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found", map[string]any{"status_page_id": pageID})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+
+	components, appErr := resolveComponents(page, req.ComponentIDs)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	now := time.Now().UTC()
+	incident := &models.StatusPageIncident{
+		PublicID:     uuid.New(),
+		StatusPageID: page.ID,
+		Title:        req.Title,
+		Status:       req.Status,
+		Components:   components,
+		Updates: []models.StatusPageIncidentUpdate{{
+			PublicID: uuid.New(),
+			Status:   req.Status,
+			Message:  req.Message,
+		}},
+	}
+	if req.Status == models.IncidentResolved {
+		incident.ResolvedAt = &now
+	}
+
+	if err := s.incidentRepo.Create(ctx, s.db, incident); err != nil {
+		utils.Error(ctx, "Failed to create status page incident", map[string]any{"status_page_id": pageID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error creating incident", err)
+	}
+
+	s.notifySubscribers(ctx, page, incident, &incident.Updates[0], appUrl)
+
+	utils.Info(ctx, "Status page incident created successfully", map[string]any{"incident_id": incident.PublicID})
+	return newStatusIncidentResponse(incident), nil
+}
+
+func (s *statusIncidentService) AddUpdate(ctx context.Context, req *StatusIncidentUpdateRequest, userID uint, pageID, incidentID uuid.UUID, appUrl string) (*StatusIncidentResponse, *utils.AppError) {
+	utils.Info(ctx, "Adding status page incident update", map[string]any{"status_page_id": pageID, "incident_id": incidentID})
+
+	page, err := s.pageRepo.FindByPublicID(ctx, s.db, userID, pageID)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found", map[string]any{"status_page_id": pageID})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+
+	incident, err := s.incidentRepo.FindByPublicID(ctx, s.db, page.ID, incidentID)
+	if err != nil {
+		utils.Warn(ctx, "Status page incident not found", map[string]any{"incident_id": incidentID})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Incident not found", err)
+	}
+
+	if req.ComponentIDs != nil {
+		components, appErr := resolveComponents(page, req.ComponentIDs)
+		if appErr != nil {
+			return nil, appErr
+		}
+		incident.Components = components
+	}
+
+	incident.Status = req.Status
+	if req.Status == models.IncidentResolved {
+		now := time.Now().UTC()
+		incident.ResolvedAt = &now
+	} else {
+		incident.ResolvedAt = nil
+	}
+
+	update := &models.StatusPageIncidentUpdate{
+		PublicID:             uuid.New(),
+		StatusPageIncidentID: incident.ID,
+		Status:               req.Status,
+		Message:              req.Message,
+	}
+
+	err = db.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.incidentRepo.Update(ctx, tx, incident); err != nil {
+			return err
+		}
+		return s.incidentRepo.AddUpdate(ctx, tx, update)
+	})
+	if err != nil {
+		utils.Error(ctx, "Failed to add status page incident update", map[string]any{"incident_id": incidentID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error updating incident", err)
+	}
+	incident.Updates = append([]models.StatusPageIncidentUpdate{*update}, incident.Updates...)
+
+	s.notifySubscribers(ctx, page, incident, update, appUrl)
+
+	utils.Info(ctx, "Status page incident updated successfully", map[string]any{"incident_id": incidentID})
+	return newStatusIncidentResponse(incident), nil
+}
+
+func (s *statusIncidentService) Delete(ctx context.Context, userID uint, pageID, incidentID uuid.UUID) *utils.AppError {
+	utils.Info(ctx, "Deleting status page incident", map[string]any{"status_page_id": pageID, "incident_id": incidentID})
+
+	page, err := s.pageRepo.FindByPublicID(ctx, s.db, userID, pageID)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found", map[string]any{"status_page_id": pageID})
+		return utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+
+	incident, err := s.incidentRepo.FindByPublicID(ctx, s.db, page.ID, incidentID)
+	if err != nil {
+		utils.Warn(ctx, "Status page incident not found", map[string]any{"incident_id": incidentID})
+		return utils.NewAppError(http.StatusNotFound, utils.NotFound, "Incident not found", err)
+	}
+
+	if err := s.incidentRepo.Delete(ctx, s.db, incident); err != nil {
+		utils.Error(ctx, "Failed to delete status page incident", map[string]any{"incident_id": incidentID, "err": err.Error()})
+		return utils.InternalServerError("Error deleting incident", err)
+	}
+
+	utils.Info(ctx, "Status page incident deleted successfully", map[string]any{"incident_id": incidentID})
+	return nil
+}
+
+func (s *statusIncidentService) ListByStatusPage(ctx context.Context, userID uint, pageID uuid.UUID) ([]StatusIncidentResponse, *utils.AppError) {
+	page, err := s.pageRepo.FindByPublicID(ctx, s.db, userID, pageID)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found", map[string]any{"status_page_id": pageID})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+
+	incidents, err := s.incidentRepo.ListByStatusPageID(ctx, s.db, page.ID, nil)
+	if err != nil {
+		utils.Error(ctx, "Failed to list status page incidents", map[string]any{"status_page_id": pageID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error listing incidents", err)
+	}
+
+	responses := []StatusIncidentResponse{}
+	for i := range incidents {
+		responses = append(responses, *newStatusIncidentResponse(&incidents[i]))
+	}
+	return responses, nil
+}
+
+// notifySubscribers emails an incident update to every confirmed subscriber of the page.
+func (s *statusIncidentService) notifySubscribers(ctx context.Context, page *models.StatusPage, incident *models.StatusPageIncident, update *models.StatusPageIncidentUpdate, appUrl string) {
+	subscribers, err := s.subscriberRepo.ListByStatusPageID(ctx, s.db, page.ID, true)
+	if err != nil {
+		utils.Error(ctx, "Failed to list status page subscribers", map[string]any{"status_page_id": page.PublicID, "err": err.Error()})
+		return
+	}
+
+	loc, err := ownerLocation(ctx, s.db, s.urlRepo, page.UserID)
+	if err != nil {
+		utils.Warn(ctx, "Failed to get user timezone", map[string]any{"user_id": page.UserID, "err": err.Error()})
+		loc, _ = time.LoadLocation(utils.DefaultTimezone)
+	}
+
+	names := make([]string, 0, len(incident.Components))
+	for _, component := range incident.Components {
+		names = append(names, component.Name)
+	}
+
+	status := incidentStatusLabels[update.Status]
+	subject := fmt.Sprintf("[%s] %s - %s", page.Title, status, incident.Title)
+
+	for _, subscriber := range subscribers {
+		err := tasks.EnqueueEmail(ctx, s.asyncClient, subscriber.Email, subject, email.EmailStatusUpdate, map[string]any{
+			"LogoURL":         fmt.Sprintf("%s/icon.png", appUrl),
+			"PageTitle":       page.Title,
+			"IncidentTitle":   incident.Title,
+			"Status":          status,
+			"Message":         update.Message,
+			"Components":      strings.Join(names, ", "),
+			"UpdatedAt":       update.CreatedAt.In(loc).Format("2006-01-02 15:04:05"),
+			"PageLink":        statusPageLink(appUrl, page.Slug),
+			"UnsubscribeLink": fmt.Sprintf("%s/unsubscribe?token=%s", statusPageLink(appUrl, page.Slug), subscriber.UnsubscribeToken),
+		})
+		if err != nil {
+			utils.Error(ctx, "Failed to enqueue status update email", map[string]any{"subscriber_id": subscriber.PublicID, "err": err.Error()})
+		}
+	}
+
+	utils.Info(ctx, "Status page subscribers notified", map[string]any{"status_page_id": page.PublicID, "count": len(subscribers)})
+}
+
+func resolveComponents(page *models.StatusPage, componentIDs []uuid.UUID) ([]models.StatusPageComponent, *utils.AppError) {
+	byPublicID := map[uuid.UUID]models.StatusPageComponent{}
+	for _, component := range page.Components {
+		byPublicID[component.PublicID] = component
+	}
+
+	components := []models.StatusPageComponent{}
+	for _, id := range componentIDs {
+		component, ok := byPublicID[id]
+		if !ok {
+			return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Component not found", nil)
+		}
+		component.URL = models.URL{}
+		components = append(components, component)
+	}
+	return components, nil
+}
+
+func statusPageLink(appUrl, slug string) string {
+	return fmt.Sprintf("%s/status/%s", appUrl, slug)
+}
+
+func newStatusIncidentResponse(incident *models.StatusPageIncident) *StatusIncidentResponse {
+	components := []StatusIncidentComponent{}
+	for _, component := range incident.Components {
+		components = append(components, StatusIncidentComponent{ID: component.PublicID, Name: component.Name})
+	}
+
+	updates := []StatusIncidentUpdateResponse{}
+	for _, update := range incident.Updates {
+		updates = append(updates, StatusIncidentUpdateResponse{
+			ID:        update.PublicID,
+			Status:    update.Status,
+			Message:   update.Message,
+			CreatedAt: update.CreatedAt,
+		})
+	}
+
+	return &StatusIncidentResponse{
+		ID:         incident.PublicID,
+		Title:      incident.Title,
+		Status:     incident.Status,
+		Components: components,
+		Updates:    updates,
+		ResolvedAt: incident.ResolvedAt,
+		CreatedAt:  incident.CreatedAt,
+		UpdatedAt:  incident.UpdatedAt,
+	}
+}
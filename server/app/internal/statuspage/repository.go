@@ -0,0 +1,122 @@
+package statuspage
+
+import (
+	"context"
+	"uptimatic/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StatusPageRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, page *models.StatusPage) error
+	Update(ctx context.Context, tx *gorm.DB, page *models.StatusPage) error
+	Delete(ctx context.Context, tx *gorm.DB, page *models.StatusPage) error
+	FindByPublicID(ctx context.Context, tx *gorm.DB, userID uint, publicID uuid.UUID) (*models.StatusPage, error)
+	FindBySlug(ctx context.Context, tx *gorm.DB, slug string) (*models.StatusPage, error)
+	ListByUserID(ctx context.Context, tx *gorm.DB, userID uint) ([]models.StatusPage, error)
+	ListPublic(ctx context.Context, tx *gorm.DB) ([]models.StatusPage, error)
+	LogoInUse(ctx context.Context, tx *gorm.DB, logo string) (bool, error)
+}
+
+type statusPageRepository struct{}
+
+func NewStatusPageRepository() StatusPageRepository {
+	return &statusPageRepository{}
+}
+
+func preloadComponents(tx *gorm.DB) *gorm.DB {
+	return tx.
+		Preload("Components", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Components.URL")
+}
+
+func (r *statusPageRepository) Create(ctx context.Context, tx *gorm.DB, page *models.StatusPage) error {
+	return tx.WithContext(ctx).Create(page).Error
+}
+
+// Update saves the page and syncs its components by public ID. Components that are kept retain
+// their row, so announcements linked to them stay linked; only removed components are deleted.
+func (r *statusPageRepository) Update(ctx context.Context, tx *gorm.DB, page *models.StatusPage) error {
+	if err := tx.WithContext(ctx).Omit("Components").Save(page).Error; err != nil {
+		return err
+	}
+
+	kept := make([]uuid.UUID, 0, len(page.Components))
+	for _, component := range page.Components {
+		kept = append(kept, component.PublicID)
+	}
+	removed := tx.WithContext(ctx).Where("status_page_id = ?", page.ID)
+	if len(kept) > 0 {
+		removed = removed.Where("public_id NOT IN ?", kept)
+	}
+	if err := removed.Delete(&models.StatusPageComponent{}).Error; err != nil {
+		return err
+	}
+	if len(page.Components) == 0 {
+		return nil
+	}
+
+	for i := range page.Components {
+		page.Components[i].ID = 0
+		page.Components[i].StatusPageID = page.ID
+	}
+	return tx.WithContext(ctx).
+		Omit("URL").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "public_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"url_id", "name", "position"}),
+		}).
+		Create(&page.Components).Error
+}
+
+func (r *statusPageRepository) Delete(ctx context.Context, tx *gorm.DB, page *models.StatusPage) error {
+	return tx.WithContext(ctx).Delete(page).Error
+}
+
+func (r *statusPageRepository) FindByPublicID(ctx context.Context, tx *gorm.DB, userID uint, publicID uuid.UUID) (*models.StatusPage, error) {
+	var page models.StatusPage
+	err := preloadComponents(tx.WithContext(ctx)).First(&page, "public_id = ? AND user_id = ?", publicID, userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+func (r *statusPageRepository) FindBySlug(ctx context.Context, tx *gorm.DB, slug string) (*models.StatusPage, error) {
+	var page models.StatusPage
+	err := preloadComponents(tx.WithContext(ctx)).First(&page, "slug = ?", slug).Error
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+func (r *statusPageRepository) ListByUserID(ctx context.Context, tx *gorm.DB, userID uint) ([]models.StatusPage, error) {
+	var pages []models.StatusPage
+	err := preloadComponents(tx.WithContext(ctx)).Where("user_id = ?", userID).Order("title ASC").Find(&pages).Error
+	if err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+func (r *statusPageRepository) ListPublic(ctx context.Context, tx *gorm.DB) ([]models.StatusPage, error) {
+	var pages []models.StatusPage
+	err := tx.WithContext(ctx).Where("visibility = ?", models.VisibilityPublic).Order("title ASC").Find(&pages).Error
+	if err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// LogoInUse reports whether any status page still shows logo.
+func (r *statusPageRepository) LogoInUse(ctx context.Context, tx *gorm.DB, logo string) (bool, error) {
+	var count int64
+	err := tx.WithContext(ctx).Model(&models.StatusPage{}).Where("logo = ?", logo).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,45 @@
+package statuspage
+
+import (
+	"uptimatic/internal/middleware"
+	"uptimatic/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func StatusPageRoutes(r *gin.RouterGroup, h StatusPageHandler, ih StatusIncidentHandler, sh SubscriberHandler, jwtUtil *utils.JWTUtil) {
+	pages := r.Group("/status-pages")
+	pages.Use(middleware.AuthMiddleware(jwtUtil))
+	pages.Use(middleware.VerifiedMiddleware())
+	{
+		pages.POST("", h.CreateHandler)
+		pages.GET("", h.ListHandler)
+		pages.POST("/upload-url", h.GetLogoUploadURLHandler)
+		pages.GET("/:id", h.GetHandler)
+		pages.PUT("/:id", h.UpdateHandler)
+		pages.DELETE("/:id", h.DeleteHandler)
+
+		pages.POST("/:id/incidents", ih.CreateHandler)
+		pages.GET("/:id/incidents", ih.ListHandler)
+		pages.POST("/:id/incidents/:incident_id/updates", ih.AddUpdateHandler)
+		pages.DELETE("/:id/incidents/:incident_id", ih.DeleteHandler)
+
+		pages.GET("/:id/subscribers", sh.ListHandler)
+		pages.DELETE("/:id/subscribers/:subscriber_id", sh.RemoveHandler)
+	}
+
+	public := r.Group("/public/status-pages")
+	{
+		public.GET("", h.ListPublicHandler)
+		public.GET("/:slug", h.GetPublicHandler)
+		public.POST("/:slug/subscribe", sh.SubscribeHandler)
+		public.POST("/:slug/confirm", sh.ConfirmHandler)
+		public.POST("/:slug/unsubscribe", sh.UnsubscribeHandler)
+
+		public.GET("/:slug/feed.rss", h.RSSFeedHandler)
+		public.GET("/:slug/feed.atom", h.AtomFeedHandler)
+		public.GET("/:slug/api/v2/status.json", h.V2StatusHandler)
+		public.GET("/:slug/api/v2/summary.json", h.V2SummaryHandler)
+		public.GET("/:slug/api/v2/incidents.json", h.V2IncidentsHandler)
+	}
+}
@@ -0,0 +1,212 @@
+package statuspage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StatusPageRequest struct {
+	Slug        string             `json:"slug" validate:"required,min=3,max=64"`
+	Title       string             `json:"title" validate:"required"`
+	Description string             `json:"description"`
+	Logo        string             `json:"logo"`
+	Visibility  string             `json:"visibility" validate:"required,oneof=public unlisted password"`
+	Password    string             `json:"password"`
+	Components  []ComponentRequest `json:"components" validate:"dive"`
+}
+
+type ComponentRequest struct {
+	URLID uuid.UUID `json:"url_id" validate:"required"`
+	Name  string    `json:"name" validate:"required"`
+}
+
+type LogoUploadRequest struct {
+	FileName    string `json:"file_name" validate:"required"`
+	ContentType string `json:"content_type" validate:"required"`
+}
+
+type StatusPageResponse struct {
+	ID          uuid.UUID           `json:"id"`
+	Slug        string              `json:"slug"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Logo        string              `json:"logo"`
+	Visibility  string              `json:"visibility"`
+	Components  []ComponentResponse `json:"components"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+type ComponentResponse struct {
+	ID       uuid.UUID `json:"id"`
+	URLID    uuid.UUID `json:"url_id"`
+	Name     string    `json:"name"`
+	Position int       `json:"position"`
+}
+
+type PublicStatusPageSummary struct {
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Logo        string `json:"logo"`
+}
+
+type PublicStatusPageResponse struct {
+	Slug            string                    `json:"slug"`
+	Title           string                    `json:"title"`
+	Description     string                    `json:"description"`
+	Logo            string                    `json:"logo"`
+	Status          string                    `json:"status"`
+	Components      []PublicComponentResponse `json:"components"`
+	ActiveIncidents []PublicIncidentResponse  `json:"active_incidents"`
+	Announcements   []StatusIncidentResponse  `json:"announcements"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
+}
+
+type PublicComponentResponse struct {
+	ID            uuid.UUID   `json:"id"`
+	Name          string      `json:"name"`
+	Status        string      `json:"status"`
+	UptimePercent float64     `json:"uptime_percent"`
+	Bars          []UptimeBar `json:"bars"`
+}
+
+type UptimeBar struct {
+	Date          string   `json:"date"`
+	TotalChecks   int      `json:"total_checks"`
+	UptimePercent *float64 `json:"uptime_percent"`
+}
+
+type PublicIncidentResponse struct {
+	ID          uuid.UUID `json:"id"`
+	ComponentID uuid.UUID `json:"component_id"`
+	Component   string    `json:"component"`
+	StartedAt   time.Time `json:"started_at"`
+}
+
+type StatusIncidentRequest struct {
+	Title        string      `json:"title" validate:"required"`
+	Status       string      `json:"status" validate:"required,oneof=investigating identified monitoring resolved"`
+	Message      string      `json:"message" validate:"required"`
+	ComponentIDs []uuid.UUID `json:"component_ids"`
+}
+
+type StatusIncidentUpdateRequest struct {
+	Status       string      `json:"status" validate:"required,oneof=investigating identified monitoring resolved"`
+	Message      string      `json:"message" validate:"required"`
+	ComponentIDs []uuid.UUID `json:"component_ids"`
+}
+
+type StatusIncidentResponse struct {
+	ID         uuid.UUID                      `json:"id"`
+	Title      string                         `json:"title"`
+	Status     string                         `json:"status"`
+	Components []StatusIncidentComponent      `json:"components"`
+	Updates    []StatusIncidentUpdateResponse `json:"updates"`
+	ResolvedAt *time.Time                     `json:"resolved_at"`
+	CreatedAt  time.Time                      `json:"created_at"`
+	UpdatedAt  time.Time                      `json:"updated_at"`
+}
+
+type StatusIncidentComponent struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type StatusIncidentUpdateResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SubscribeRequest struct {
+	Email string `json:"email" validate:"email,required"`
+}
+
+type SubscriberResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Email       string     `json:"email"`
+	Confirmed   bool       `json:"confirmed"`
+	ConfirmedAt *time.Time `json:"confirmed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type V2Page struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	TimeZone  string    `json:"time_zone"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type V2Status struct {
+	Indicator   string `json:"indicator"`
+	Description string `json:"description"`
+}
+
+type V2Component struct {
+	ID                 string    `json:"id"`
+	Name               string    `json:"name"`
+	Status             string    `json:"status"`
+	Position           int       `json:"position"`
+	Description        *string   `json:"description"`
+	Showcase           bool      `json:"showcase"`
+	GroupID            *string   `json:"group_id"`
+	PageID             string    `json:"page_id"`
+	Group              bool      `json:"group"`
+	OnlyShowIfDegraded bool      `json:"only_show_if_degraded"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type V2Incident struct {
+	ID              string             `json:"id"`
+	Name            string             `json:"name"`
+	Status          string             `json:"status"`
+	Impact          string             `json:"impact"`
+	Shortlink       string             `json:"shortlink"`
+	PageID          string             `json:"page_id"`
+	IncidentUpdates []V2IncidentUpdate `json:"incident_updates"`
+	Components      []V2Component      `json:"components"`
+	MonitoringAt    *time.Time         `json:"monitoring_at"`
+	ResolvedAt      *time.Time         `json:"resolved_at"`
+	StartedAt       time.Time          `json:"started_at"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+type V2IncidentUpdate struct {
+	ID         string    `json:"id"`
+	Status     string    `json:"status"`
+	Body       string    `json:"body"`
+	IncidentID string    `json:"incident_id"`
+	DisplayAt  time.Time `json:"display_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// V2ScheduledMaintenance is a Statuspage scheduled maintenance, an incident with the window it covers.
+type V2ScheduledMaintenance struct {
+	V2Incident
+	ScheduledFor   time.Time `json:"scheduled_for"`
+	ScheduledUntil time.Time `json:"scheduled_until"`
+}
+
+type V2StatusResponse struct {
+	Page   V2Page   `json:"page"`
+	Status V2Status `json:"status"`
+}
+
+type V2SummaryResponse struct {
+	Page                  V2Page                   `json:"page"`
+	Components            []V2Component            `json:"components"`
+	Incidents             []V2Incident             `json:"incidents"`
+	ScheduledMaintenances []V2ScheduledMaintenance `json:"scheduled_maintenances"`
+	Status                V2Status                 `json:"status"`
+}
+
+type V2IncidentsResponse struct {
+	Page      V2Page       `json:"page"`
+	Incidents []V2Incident `json:"incidents"`
+}
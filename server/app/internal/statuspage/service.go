@@ -0,0 +1,560 @@
+package statuspage
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+	"uptimatic/internal/adapters/minio"
+	"uptimatic/internal/db"
+	"uptimatic/internal/models"
+	"uptimatic/internal/url"
+	"uptimatic/internal/utils"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	StatusOperational      = "operational"
+	StatusPartialOutage    = "partial_outage"
+	StatusMajorOutage      = "major_outage"
+	StatusUnderMaintenance = "under_maintenance"
+	StatusUnknown          = "unknown"
+)
+
+const (
+	uptimeBarDays     = 90
+	announcementsDays = 7
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// logoPattern matches the object keys GetLogoUploadURL hands out; the first group is the owner's id,
+// which logos uploaded before keys were scoped per user lack.
+var logoPattern = regexp.MustCompile(`^status-pages/(?:(\d+)/)?[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}(?:\.[A-Za-z0-9]{1,10})?$`)
+
+// logoExtPattern limits the extension kept from an uploaded logo's file name.
+var logoExtPattern = regexp.MustCompile(`^\.[A-Za-z0-9]{1,10}$`)
+
+type StatusPageService interface {
+	Create(ctx context.Context, req *StatusPageRequest, userID uint) (*StatusPageResponse, *utils.AppError)
+	Update(ctx context.Context, req *StatusPageRequest, userID uint, id uuid.UUID) (*StatusPageResponse, *utils.AppError)
+	Delete(ctx context.Context, userID uint, id uuid.UUID) *utils.AppError
+	FindByID(ctx context.Context, userID uint, id uuid.UUID) (*StatusPageResponse, *utils.AppError)
+	ListByUserID(ctx context.Context, userID uint) ([]StatusPageResponse, *utils.AppError)
+	GetLogoUploadURL(ctx context.Context, userID uint, fileName, contentType string) (string, string, *utils.AppError)
+	ListPublic(ctx context.Context) ([]PublicStatusPageSummary, *utils.AppError)
+	GetPublic(ctx context.Context, slug, password string) (*PublicStatusPageResponse, *utils.AppError)
+	GetFeed(ctx context.Context, slug, password, format, appUrl string) ([]byte, *utils.AppError)
+	GetV2Status(ctx context.Context, slug, password, appUrl string) (*V2StatusResponse, *utils.AppError)
+	GetV2Summary(ctx context.Context, slug, password, appUrl string) (*V2SummaryResponse, *utils.AppError)
+	GetV2Incidents(ctx context.Context, slug, password, appUrl string) (*V2IncidentsResponse, *utils.AppError)
+}
+
+type statusPageService struct {
+	db               *gorm.DB
+	pageRepo         StatusPageRepository
+	urlRepo          url.UrlRepository
+	logRepo          url.StatusLogRepository
+	maintenanceRepo  url.MaintenanceRepository
+	incidentRepo     url.IncidentRepository
+	announcementRepo StatusIncidentRepository
+	minio            *minio.MinioUtil
+}
+
+func NewStatusPageService(db *gorm.DB, pageRepo StatusPageRepository, urlRepo url.UrlRepository, logRepo url.StatusLogRepository, maintenanceRepo url.MaintenanceRepository, incidentRepo url.IncidentRepository, announcementRepo StatusIncidentRepository, minio *minio.MinioUtil) StatusPageService {
+	return &statusPageService{db, pageRepo, urlRepo, logRepo, maintenanceRepo, incidentRepo, announcementRepo, minio}
+}
+
+func (s *statusPageService) Create(ctx context.Context, req *StatusPageRequest, userID uint) (*StatusPageResponse, *utils.AppError) {
+	utils.Info(ctx, "Creating status page", map[string]any{"user_id": userID, "slug": req.Slug})
+
+	page := &models.StatusPage{
+		UserID:   userID,
+		PublicID: uuid.New(),
+	}
+	if appErr := s.apply(ctx, page, req); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := s.pageRepo.Create(ctx, s.db, page); err != nil {
+		utils.Error(ctx, "Failed to create status page", map[string]any{"user_id": userID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error creating status page", err)
+	}
+
+	utils.Info(ctx, "Status page created successfully", map[string]any{"status_page_id": page.PublicID, "user_id": userID})
+	return s.FindByID(ctx, userID, page.PublicID)
+}
+
+func (s *statusPageService) Update(ctx context.Context, req *StatusPageRequest, userID uint, id uuid.UUID) (*StatusPageResponse, *utils.AppError) {
+	utils.Info(ctx, "Updating status page", map[string]any{"status_page_id": id})
+
+	page, err := s.pageRepo.FindByPublicID(ctx, s.db, userID, id)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found for update", map[string]any{"status_page_id": id})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+
+	oldLogo := page.Logo
+	if appErr := s.apply(ctx, page, req); appErr != nil {
+		return nil, appErr
+	}
+
+	err = db.WithTransaction(s.db, func(tx *gorm.DB) error {
+		return s.pageRepo.Update(ctx, tx, page)
+	})
+	if err != nil {
+		utils.Error(ctx, "Failed to update status page", map[string]any{"status_page_id": id, "err": err.Error()})
+		return nil, utils.InternalServerError("Error updating status page", err)
+	}
+
+	if oldLogo != page.Logo {
+		s.deleteLogo(ctx, page, oldLogo)
+	}
+
+	utils.Info(ctx, "Status page updated successfully", map[string]any{"status_page_id": id})
+	return s.FindByID(ctx, userID, id)
+}
+
+func (s *statusPageService) Delete(ctx context.Context, userID uint, id uuid.UUID) *utils.AppError {
+	utils.Info(ctx, "Deleting status page", map[string]any{"status_page_id": id})
+
+	page, err := s.pageRepo.FindByPublicID(ctx, s.db, userID, id)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found for deletion", map[string]any{"status_page_id": id})
+		return utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+
+	if err := s.pageRepo.Delete(ctx, s.db, page); err != nil {
+		utils.Error(ctx, "Failed to delete status page", map[string]any{"status_page_id": id, "err": err.Error()})
+		return utils.InternalServerError("Error deleting status page", err)
+	}
+
+	s.deleteLogo(ctx, page, page.Logo)
+
+	utils.Info(ctx, "Status page deleted successfully", map[string]any{"status_page_id": id})
+	return nil
+}
+
+func (s *statusPageService) FindByID(ctx context.Context, userID uint, id uuid.UUID) (*StatusPageResponse, *utils.AppError) {
+	page, err := s.pageRepo.FindByPublicID(ctx, s.db, userID, id)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found", map[string]any{"status_page_id": id})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+	return s.newStatusPageResponse(ctx, page), nil
+}
+
+func (s *statusPageService) ListByUserID(ctx context.Context, userID uint) ([]StatusPageResponse, *utils.AppError) {
+	pages, err := s.pageRepo.ListByUserID(ctx, s.db, userID)
+	if err != nil {
+		utils.Error(ctx, "Failed to list status pages", map[string]any{"user_id": userID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error listing status pages", err)
+	}
+
+	responses := []StatusPageResponse{}
+	for i := range pages {
+		responses = append(responses, *s.newStatusPageResponse(ctx, &pages[i]))
+	}
+	return responses, nil
+}
+
+func (s *statusPageService) GetLogoUploadURL(ctx context.Context, userID uint, fileName, contentType string) (string, string, *utils.AppError) {
+	ext := filepath.Ext(fileName)
+	if !logoExtPattern.MatchString(ext) {
+		ext = ""
+	}
+	fileName = "status-pages/" + strconv.FormatUint(uint64(userID), 10) + "/" + uuid.New().String() + ext
+	utils.Info(ctx, "Generating presigned URL for status page logo", map[string]any{"file": fileName, "user_id": userID})
+
+	uploadURL, err := s.minio.PutPresignedURL(ctx, fileName, contentType)
+	if err != nil {
+		return "", "", utils.InternalServerError("Error generating presigned URL", err)
+	}
+
+	return uploadURL, fileName, nil
+}
+
+func (s *statusPageService) ListPublic(ctx context.Context) ([]PublicStatusPageSummary, *utils.AppError) {
+	pages, err := s.pageRepo.ListPublic(ctx, s.db)
+	if err != nil {
+		utils.Error(ctx, "Failed to list public status pages", map[string]any{"err": err.Error()})
+		return nil, utils.InternalServerError("Error listing status pages", err)
+	}
+
+	summaries := []PublicStatusPageSummary{}
+	for _, page := range pages {
+		summaries = append(summaries, PublicStatusPageSummary{
+			Slug:        page.Slug,
+			Title:       page.Title,
+			Description: page.Description,
+			Logo:        s.logoURL(ctx, page.Logo),
+		})
+	}
+	return summaries, nil
+}
+
+func (s *statusPageService) GetPublic(ctx context.Context, slug, password string) (*PublicStatusPageResponse, *utils.AppError) {
+	page, appErr := findAccessiblePage(ctx, s.db, s.pageRepo, slug, password)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return s.buildPublicStatus(ctx, page)
+}
+
+// findAccessiblePage loads a page by slug and enforces its password when it has one.
+func findAccessiblePage(ctx context.Context, tx *gorm.DB, pageRepo StatusPageRepository, slug, password string) (*models.StatusPage, *utils.AppError) {
+	page, err := pageRepo.FindBySlug(ctx, tx, slug)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found", map[string]any{"slug": slug})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+
+	if page.Visibility == models.VisibilityPassword {
+		if password == "" {
+			return nil, utils.NewAppError(http.StatusUnauthorized, utils.Unauthorized, "Password required", nil)
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(page.Password), []byte(password)); err != nil {
+			return nil, utils.NewAppError(http.StatusUnauthorized, utils.InvalidCredentials, "Invalid password", err)
+		}
+	}
+
+	return page, nil
+}
+
+// ownerLocation returns the time zone of the page owner, so the daily bars line up with the
+// days on the owner's dashboard.
+func ownerLocation(ctx context.Context, tx *gorm.DB, urlRepo url.UrlRepository, userID uint) (*time.Location, error) {
+	timezone, err := urlRepo.GetOwnerTimezone(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if timezone == "" {
+		timezone = utils.DefaultTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc, _ = time.LoadLocation(utils.DefaultTimezone)
+	}
+	return loc, nil
+}
+
+func (s *statusPageService) pageLocation(ctx context.Context, page *models.StatusPage) (*time.Location, *utils.AppError) {
+	loc, err := ownerLocation(ctx, s.db, s.urlRepo, page.UserID)
+	if err != nil {
+		utils.Error(ctx, "Failed to get user timezone", map[string]any{"user_id": page.UserID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading timezone", err)
+	}
+	return loc, nil
+}
+
+func (s *statusPageService) buildPublicStatus(ctx context.Context, page *models.StatusPage) (*PublicStatusPageResponse, *utils.AppError) {
+	urlIDs := make([]uint, 0, len(page.Components))
+	for _, component := range page.Components {
+		urlIDs = append(urlIDs, component.URLID)
+	}
+
+	lastLogs, err := s.logRepo.ListLastLogsByURLIDs(ctx, s.db, urlIDs)
+	if err != nil {
+		utils.Error(ctx, "Failed to load last status logs", map[string]any{"slug": page.Slug, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading status page", err)
+	}
+	lastByURL := map[uint]models.StatusLog{}
+	for _, log := range lastLogs {
+		lastByURL[log.URLID] = log
+	}
+
+	now := time.Now()
+	windows, err := s.maintenanceRepo.ListActiveByURLIDs(ctx, s.db, urlIDs)
+	if err != nil {
+		utils.Error(ctx, "Failed to load maintenance windows", map[string]any{"slug": page.Slug, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading status page", err)
+	}
+	inMaintenance := url.MaintenanceURLIDs(windows, now)
+
+	loc, appErr := s.pageLocation(ctx, page)
+	if appErr != nil {
+		return nil, appErr
+	}
+	local := now.In(loc)
+	end := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	start := end.AddDate(0, 0, -uptimeBarDays)
+
+	stats, err := s.logRepo.GetDailyUptimeByURLIDs(ctx, s.db, urlIDs, loc.String(), start.UTC(), end.UTC())
+	if err != nil {
+		utils.Error(ctx, "Failed to load daily uptime", map[string]any{"slug": page.Slug, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading status page", err)
+	}
+	statsByURL := map[uint]map[string]models.UptimeStat{}
+	for _, stat := range stats {
+		if statsByURL[stat.URLID] == nil {
+			statsByURL[stat.URLID] = map[string]models.UptimeStat{}
+		}
+		statsByURL[stat.URLID][stat.BucketStart.In(loc).Format("2006-01-02")] = stat.UptimeStat
+	}
+
+	incidents, err := s.incidentRepo.ListOpenByURLIDs(ctx, s.db, urlIDs)
+	if err != nil {
+		utils.Error(ctx, "Failed to load incidents", map[string]any{"slug": page.Slug, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading status page", err)
+	}
+
+	since := now.AddDate(0, 0, -announcementsDays)
+	announcements, err := s.announcementRepo.ListByStatusPageID(ctx, s.db, page.ID, &since)
+	if err != nil {
+		utils.Error(ctx, "Failed to load announcements", map[string]any{"slug": page.Slug, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading status page", err)
+	}
+
+	resp := &PublicStatusPageResponse{
+		Slug:            page.Slug,
+		Title:           page.Title,
+		Description:     page.Description,
+		Logo:            s.logoURL(ctx, page.Logo),
+		Components:      []PublicComponentResponse{},
+		ActiveIncidents: []PublicIncidentResponse{},
+		Announcements:   []StatusIncidentResponse{},
+		UpdatedAt:       now.UTC(),
+	}
+
+	for i := range announcements {
+		resp.Announcements = append(resp.Announcements, *newStatusIncidentResponse(&announcements[i]))
+	}
+
+	componentsByURL := map[uint]models.StatusPageComponent{}
+	for _, component := range page.Components {
+		componentsByURL[component.URLID] = component
+
+		status := StatusUnknown
+		if log, ok := lastByURL[component.URLID]; ok {
+			status = componentStatus(log, inMaintenance[component.URLID])
+		} else if inMaintenance[component.URLID] {
+			status = StatusUnderMaintenance
+		}
+
+		bars := make([]UptimeBar, 0, uptimeBarDays)
+		upChecks, countedChecks := 0, 0
+		for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+			date := day.Format("2006-01-02")
+			bar := UptimeBar{Date: date}
+			if stat, ok := statsByURL[component.URLID][date]; ok {
+				percent := stat.UptimePercent
+				bar.TotalChecks = stat.TotalChecks
+				bar.UptimePercent = &percent
+				upChecks += stat.UpChecks
+				countedChecks += stat.TotalChecks - stat.MaintenanceChecks
+			}
+			bars = append(bars, bar)
+		}
+
+		uptime := 100.0
+		if countedChecks > 0 {
+			uptime = float64(upChecks*10000/countedChecks) / 100
+		}
+
+		resp.Components = append(resp.Components, PublicComponentResponse{
+			ID:            component.PublicID,
+			Name:          component.Name,
+			Status:        status,
+			UptimePercent: uptime,
+			Bars:          bars,
+		})
+	}
+
+	for _, incident := range incidents {
+		component := componentsByURL[incident.URLID]
+		resp.ActiveIncidents = append(resp.ActiveIncidents, PublicIncidentResponse{
+			ID:          incident.PublicID,
+			ComponentID: component.PublicID,
+			Component:   component.Name,
+			StartedAt:   incident.StartedAt,
+		})
+	}
+
+	resp.Status = overallStatus(resp.Components)
+	return resp, nil
+}
+
+func componentStatus(log models.StatusLog, inMaintenance bool) string {
+	if inMaintenance {
+		return StatusUnderMaintenance
+	}
+	if code, err := strconv.Atoi(log.Status); err == nil && url.IsDown(code) {
+		return StatusMajorOutage
+	}
+	return StatusOperational
+}
+
+func overallStatus(components []PublicComponentResponse) string {
+	down, maintenance := 0, 0
+	for _, component := range components {
+		switch component.Status {
+		case StatusMajorOutage:
+			down++
+		case StatusUnderMaintenance:
+			maintenance++
+		}
+	}
+
+	switch {
+	case down > 0 && down == len(components):
+		return StatusMajorOutage
+	case down > 0:
+		return StatusPartialOutage
+	case maintenance > 0:
+		return StatusUnderMaintenance
+	default:
+		return StatusOperational
+	}
+}
+
+// apply validates the request and copies it onto the page, resolving component monitors for the owner.
+func (s *statusPageService) apply(ctx context.Context, page *models.StatusPage, req *StatusPageRequest) *utils.AppError {
+	if !slugPattern.MatchString(req.Slug) {
+		return utils.ValidationErrorErr(map[string][]map[string]any{
+			"slug": {{"code": utils.InvalidFormat}},
+		})
+	}
+
+	existing, err := s.pageRepo.FindBySlug(ctx, s.db, req.Slug)
+	if err == nil && existing.ID != page.ID {
+		return utils.UniqueFieldError("slug")
+	}
+
+	if req.Visibility == models.VisibilityPassword {
+		switch {
+		case req.Password != "":
+			hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+			if err != nil {
+				return utils.InternalServerError("Error hashing password", err)
+			}
+			page.Password = string(hashed)
+		case page.Password == "":
+			return utils.ValidationErrorErr(map[string][]map[string]any{
+				"password": {{"code": utils.Required}},
+			})
+		}
+	} else {
+		page.Password = ""
+	}
+
+	urlIDs := make([]uuid.UUID, 0, len(req.Components))
+	for _, component := range req.Components {
+		urlIDs = append(urlIDs, component.URLID)
+	}
+
+	urls := []models.URL{}
+	if len(urlIDs) > 0 {
+		urls, err = s.urlRepo.ListByPublicIDs(ctx, s.db, page.UserID, urlIDs)
+		if err != nil {
+			utils.Error(ctx, "Failed to resolve status page URLs", map[string]any{"user_id": page.UserID, "err": err.Error()})
+			return utils.InternalServerError("Error finding urls", err)
+		}
+	}
+	urlsByPublicID := map[uuid.UUID]models.URL{}
+	for _, u := range urls {
+		urlsByPublicID[u.PublicID] = u
+	}
+
+	existingComponents := map[uint]uuid.UUID{}
+	for _, component := range page.Components {
+		existingComponents[component.URLID] = component.PublicID
+	}
+
+	components := []models.StatusPageComponent{}
+	seen := map[uint]bool{}
+	for i, component := range req.Components {
+		u, ok := urlsByPublicID[component.URLID]
+		if !ok {
+			return utils.NewAppError(http.StatusNotFound, utils.NotFound, "Url not found", nil)
+		}
+		if seen[u.ID] {
+			return utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Duplicate component url", nil)
+		}
+		seen[u.ID] = true
+
+		publicID, ok := existingComponents[u.ID]
+		if !ok {
+			publicID = uuid.New()
+		}
+		components = append(components, models.StatusPageComponent{
+			PublicID: publicID,
+			URLID:    u.ID,
+			Name:     component.Name,
+			Position: i,
+		})
+	}
+
+	if req.Logo != page.Logo && req.Logo != "" && !ownsLogo(page.UserID, req.Logo) {
+		return utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid logo", nil)
+	}
+
+	page.Slug = req.Slug
+	page.Title = req.Title
+	page.Description = req.Description
+	page.Logo = req.Logo
+	page.Visibility = req.Visibility
+	page.Components = components
+	return nil
+}
+
+// ownsLogo reports whether logo is an object key GetLogoUploadURL issued to userID.
+func ownsLogo(userID uint, logo string) bool {
+	match := logoPattern.FindStringSubmatch(logo)
+	return match != nil && match[1] == strconv.FormatUint(uint64(userID), 10)
+}
+
+// deleteLogo removes a logo the page no longer uses, as long as the page's owner uploaded it and
+// none of their other pages still shows it.
+func (s *statusPageService) deleteLogo(ctx context.Context, page *models.StatusPage, logo string) {
+	if !ownsLogo(page.UserID, logo) {
+		return
+	}
+
+	inUse, err := s.pageRepo.LogoInUse(ctx, s.db, logo)
+	if err != nil {
+		utils.Warn(ctx, "Failed to check status page logo usage", map[string]any{"status_page_id": page.PublicID, "err": err.Error()})
+		return
+	}
+	if inUse {
+		return
+	}
+
+	if err := s.minio.DeleteFile(ctx, logo); err != nil {
+		utils.Warn(ctx, "Failed to delete status page logo", map[string]any{"status_page_id": page.PublicID, "err": err.Error()})
+	}
+}
+
+func (s *statusPageService) logoURL(ctx context.Context, logo string) string {
+	if !logoPattern.MatchString(logo) {
+		return ""
+	}
+	return s.minio.GetPublicURL(ctx, logo)
+}
+
+func (s *statusPageService) newStatusPageResponse(ctx context.Context, page *models.StatusPage) *StatusPageResponse {
+	components := []ComponentResponse{}
+	for _, component := range page.Components {
+		components = append(components, ComponentResponse{
+			ID:       component.PublicID,
+			URLID:    component.URL.PublicID,
+			Name:     component.Name,
+			Position: component.Position,
+		})
+	}
+
+	return &StatusPageResponse{
+		ID:          page.PublicID,
+		Slug:        page.Slug,
+		Title:       page.Title,
+		Description: page.Description,
+		Logo:        s.logoURL(ctx, page.Logo),
+		Visibility:  page.Visibility,
+		Components:  components,
+		CreatedAt:   page.CreatedAt,
+	}
+}
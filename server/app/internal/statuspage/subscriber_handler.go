@@ -0,0 +1,116 @@
+package statuspage
+
+import (
+	"fmt"
+	"net/http"
+	"uptimatic/internal/config"
+	"uptimatic/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type SubscriberHandler interface {
+	SubscribeHandler(c *gin.Context)
+	ConfirmHandler(c *gin.Context)
+	UnsubscribeHandler(c *gin.Context)
+	ListHandler(c *gin.Context)
+	RemoveHandler(c *gin.Context)
+}
+
+type subscriberHandler struct {
+	subscriberService SubscriberService
+	validate          *validator.Validate
+	cfg               *config.Config
+}
+
+func NewSubscriberHandler(subscriberService SubscriberService, validate *validator.Validate, cfg *config.Config) SubscriberHandler {
+	return &subscriberHandler{subscriberService, validate, cfg}
+}
+
+func (h *subscriberHandler) SubscribeHandler(c *gin.Context) {
+	var req SubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid JSON payload", err))
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		utils.BindErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	errSvc := h.subscriberService.Subscribe(c.Request.Context(), c.Param("slug"), c.GetHeader(passwordHeader), req.Email, fmt.Sprintf("%s://%s", h.cfg.AppScheme, h.cfg.AppDomain))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, nil)
+}
+
+func (h *subscriberHandler) ConfirmHandler(c *gin.Context) {
+	token, err := uuid.Parse(c.Query("token"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.InvalidToken, "Invalid token", err))
+		return
+	}
+
+	if errSvc := h.subscriberService.Confirm(c.Request.Context(), c.Param("slug"), token); errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, nil)
+}
+
+func (h *subscriberHandler) UnsubscribeHandler(c *gin.Context) {
+	token, err := uuid.Parse(c.Query("token"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.InvalidToken, "Invalid token", err))
+		return
+	}
+
+	if errSvc := h.subscriberService.Unsubscribe(c.Request.Context(), c.Param("slug"), token); errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, nil)
+}
+
+func (h *subscriberHandler) ListHandler(c *gin.Context) {
+	pageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	resp, errSvc := h.subscriberService.ListByStatusPage(c.Request.Context(), c.GetUint("user_id"), pageID)
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *subscriberHandler) RemoveHandler(c *gin.Context) {
+	pageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+	subscriberID, err := uuid.Parse(c.Param("subscriber_id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	if errSvc := h.subscriberService.Remove(c.Request.Context(), c.GetUint("user_id"), pageID, subscriberID); errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, nil)
+}
@@ -0,0 +1,107 @@
+package statuspage
+
+import (
+	"context"
+	"time"
+	"uptimatic/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SubscriberRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, subscriber *models.StatusPageSubscriber) error
+	Update(ctx context.Context, tx *gorm.DB, subscriber *models.StatusPageSubscriber) error
+	Delete(ctx context.Context, tx *gorm.DB, subscriber *models.StatusPageSubscriber) error
+	MarkConfirmationSent(ctx context.Context, tx *gorm.DB, subscriber *models.StatusPageSubscriber, cutoff time.Time) (bool, error)
+	FindByEmail(ctx context.Context, tx *gorm.DB, statusPageID uint, email string) (*models.StatusPageSubscriber, error)
+	FindByPublicID(ctx context.Context, tx *gorm.DB, statusPageID uint, publicID uuid.UUID) (*models.StatusPageSubscriber, error)
+	FindByConfirmToken(ctx context.Context, tx *gorm.DB, statusPageID uint, token uuid.UUID) (*models.StatusPageSubscriber, error)
+	FindByUnsubscribeToken(ctx context.Context, tx *gorm.DB, statusPageID uint, token uuid.UUID) (*models.StatusPageSubscriber, error)
+	ListByStatusPageID(ctx context.Context, tx *gorm.DB, statusPageID uint, confirmedOnly bool) ([]models.StatusPageSubscriber, error)
+}
+
+type subscriberRepository struct{}
+
+func NewSubscriberRepository() SubscriberRepository {
+	return &subscriberRepository{}
+}
+
+func (r *subscriberRepository) Create(ctx context.Context, tx *gorm.DB, subscriber *models.StatusPageSubscriber) error {
+	return tx.WithContext(ctx).Omit("StatusPage").Create(subscriber).Error
+}
+
+func (r *subscriberRepository) Update(ctx context.Context, tx *gorm.DB, subscriber *models.StatusPageSubscriber) error {
+	return tx.WithContext(ctx).Omit("StatusPage").Save(subscriber).Error
+}
+
+func (r *subscriberRepository) Delete(ctx context.Context, tx *gorm.DB, subscriber *models.StatusPageSubscriber) error {
+	return tx.WithContext(ctx).Delete(subscriber).Error
+}
+
+// MarkConfirmationSent stamps the confirmation time unless an email already went out after
+// cutoff. It reports whether the caller should send one, and is atomic so concurrent requests
+// for the same address send a single email.
+func (r *subscriberRepository) MarkConfirmationSent(ctx context.Context, tx *gorm.DB, subscriber *models.StatusPageSubscriber, cutoff time.Time) (bool, error) {
+	now := time.Now().UTC()
+	result := tx.WithContext(ctx).
+		Model(&models.StatusPageSubscriber{}).
+		Where("id = ? AND (confirmation_sent_at IS NULL OR confirmation_sent_at < ?)", subscriber.ID, cutoff).
+		Update("confirmation_sent_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	subscriber.ConfirmationSentAt = &now
+	return true, nil
+}
+
+func (r *subscriberRepository) FindByEmail(ctx context.Context, tx *gorm.DB, statusPageID uint, email string) (*models.StatusPageSubscriber, error) {
+	var subscriber models.StatusPageSubscriber
+	err := tx.WithContext(ctx).First(&subscriber, "status_page_id = ? AND email = ?", statusPageID, email).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscriber, nil
+}
+
+func (r *subscriberRepository) FindByPublicID(ctx context.Context, tx *gorm.DB, statusPageID uint, publicID uuid.UUID) (*models.StatusPageSubscriber, error) {
+	var subscriber models.StatusPageSubscriber
+	err := tx.WithContext(ctx).First(&subscriber, "status_page_id = ? AND public_id = ?", statusPageID, publicID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscriber, nil
+}
+
+func (r *subscriberRepository) FindByConfirmToken(ctx context.Context, tx *gorm.DB, statusPageID uint, token uuid.UUID) (*models.StatusPageSubscriber, error) {
+	var subscriber models.StatusPageSubscriber
+	err := tx.WithContext(ctx).Preload("StatusPage").First(&subscriber, "status_page_id = ? AND confirm_token = ?", statusPageID, token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscriber, nil
+}
+
+func (r *subscriberRepository) FindByUnsubscribeToken(ctx context.Context, tx *gorm.DB, statusPageID uint, token uuid.UUID) (*models.StatusPageSubscriber, error) {
+	var subscriber models.StatusPageSubscriber
+	err := tx.WithContext(ctx).Preload("StatusPage").First(&subscriber, "status_page_id = ? AND unsubscribe_token = ?", statusPageID, token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscriber, nil
+}
+
+func (r *subscriberRepository) ListByStatusPageID(ctx context.Context, tx *gorm.DB, statusPageID uint, confirmedOnly bool) ([]models.StatusPageSubscriber, error) {
+	var subscribers []models.StatusPageSubscriber
+	query := tx.WithContext(ctx).Where("status_page_id = ?", statusPageID)
+	if confirmedOnly {
+		query = query.Where("confirmed = ?", true)
+	}
+	if err := query.Order("created_at DESC").Find(&subscribers).Error; err != nil {
+		return nil, err
+	}
+	return subscribers, nil
+}
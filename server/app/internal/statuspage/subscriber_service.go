@@ -0,0 +1,196 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"uptimatic/internal/adapters/email"
+	"uptimatic/internal/models"
+	"uptimatic/internal/tasks"
+	"uptimatic/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+)
+
+// confirmationCooldown is how long Subscribe waits before mailing the same unconfirmed address
+// again, so the public endpoint cannot be used to flood an inbox.
+const confirmationCooldown = 15 * time.Minute
+
+type SubscriberService interface {
+	Subscribe(ctx context.Context, slug, password, subscriberEmail, appUrl string) *utils.AppError
+	Confirm(ctx context.Context, slug string, token uuid.UUID) *utils.AppError
+	Unsubscribe(ctx context.Context, slug string, token uuid.UUID) *utils.AppError
+	ListByStatusPage(ctx context.Context, userID uint, pageID uuid.UUID) ([]SubscriberResponse, *utils.AppError)
+	Remove(ctx context.Context, userID uint, pageID, subscriberID uuid.UUID) *utils.AppError
+}
+
+type subscriberService struct {
+	db             *gorm.DB
+	pageRepo       StatusPageRepository
+	subscriberRepo SubscriberRepository
+	asyncClient    *asynq.Client
+}
+
+func NewSubscriberService(db *gorm.DB, pageRepo StatusPageRepository, subscriberRepo SubscriberRepository, asyncClient *asynq.Client) SubscriberService {
+	return &subscriberService{db, pageRepo, subscriberRepo, asyncClient}
+}
+
+func (s *subscriberService) Subscribe(ctx context.Context, slug, password, subscriberEmail, appUrl string) *utils.AppError {
+	subscriberEmail = strings.ToLower(strings.TrimSpace(subscriberEmail))
+	utils.Info(ctx, "Subscribing to status page", map[string]any{"slug": slug, "email": subscriberEmail})
+
+	page, appErr := findAccessiblePage(ctx, s.db, s.pageRepo, slug, password)
+	if appErr != nil {
+		return appErr
+	}
+
+	subscriber, err := s.subscriberRepo.FindByEmail(ctx, s.db, page.ID, subscriberEmail)
+	if err == nil && subscriber.Confirmed {
+		utils.Info(ctx, "Subscriber already confirmed", map[string]any{"subscriber_id": subscriber.PublicID})
+		return nil
+	}
+
+	if err != nil {
+		now := time.Now().UTC()
+		subscriber = &models.StatusPageSubscriber{
+			PublicID:           uuid.New(),
+			StatusPageID:       page.ID,
+			Email:              subscriberEmail,
+			ConfirmToken:       uuid.New(),
+			UnsubscribeToken:   uuid.New(),
+			ConfirmationSentAt: &now,
+		}
+		if err := s.subscriberRepo.Create(ctx, s.db, subscriber); err != nil {
+			utils.Error(ctx, "Failed to create subscriber", map[string]any{"slug": slug, "err": err.Error()})
+			return utils.InternalServerError("Error creating subscriber", err)
+		}
+	} else {
+		send, err := s.subscriberRepo.MarkConfirmationSent(ctx, s.db, subscriber, time.Now().Add(-confirmationCooldown))
+		if err != nil {
+			utils.Error(ctx, "Failed to update subscriber", map[string]any{"subscriber_id": subscriber.PublicID, "err": err.Error()})
+			return utils.InternalServerError("Error updating subscriber", err)
+		}
+		// The response stays the same so the endpoint does not reveal whether an address is pending.
+		if !send {
+			utils.Info(ctx, "Subscription confirmation recently sent, skipping", map[string]any{"subscriber_id": subscriber.PublicID})
+			return nil
+		}
+	}
+
+	link := fmt.Sprintf("%s/confirm?token=%s", statusPageLink(appUrl, page.Slug), subscriber.ConfirmToken)
+	if err := tasks.EnqueueEmail(ctx, s.asyncClient, subscriber.Email, fmt.Sprintf("Confirm your subscription - %s", page.Title), email.EmailStatusSubscribe, map[string]any{
+		"LogoURL":     fmt.Sprintf("%s/icon.png", appUrl),
+		"PageTitle":   page.Title,
+		"ConfirmLink": link,
+	}); err != nil {
+		utils.Error(ctx, "Error sending subscription confirmation email", map[string]any{"subscriber_id": subscriber.PublicID, "err": err.Error()})
+		return utils.InternalServerError("Error sending confirmation email", err)
+	}
+
+	utils.Info(ctx, "Subscription confirmation sent", map[string]any{"subscriber_id": subscriber.PublicID})
+	return nil
+}
+
+func (s *subscriberService) Confirm(ctx context.Context, slug string, token uuid.UUID) *utils.AppError {
+	page, err := s.pageRepo.FindBySlug(ctx, s.db, slug)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found", map[string]any{"slug": slug})
+		return utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+
+	// Tokens are scoped to the page in the link, so one page's token means nothing under another slug.
+	subscriber, err := s.subscriberRepo.FindByConfirmToken(ctx, s.db, page.ID, token)
+	if err != nil {
+		utils.Warn(ctx, "Subscription confirmation token not found", nil)
+		return utils.NewAppError(http.StatusNotFound, utils.InvalidToken, "Invalid or expired token", err)
+	}
+
+	if subscriber.Confirmed {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	subscriber.Confirmed = true
+	subscriber.ConfirmedAt = &now
+	if err := s.subscriberRepo.Update(ctx, s.db, subscriber); err != nil {
+		utils.Error(ctx, "Failed to confirm subscriber", map[string]any{"subscriber_id": subscriber.PublicID, "err": err.Error()})
+		return utils.InternalServerError("Error confirming subscription", err)
+	}
+
+	utils.Info(ctx, "Subscription confirmed", map[string]any{"subscriber_id": subscriber.PublicID})
+	return nil
+}
+
+func (s *subscriberService) Unsubscribe(ctx context.Context, slug string, token uuid.UUID) *utils.AppError {
+	page, err := s.pageRepo.FindBySlug(ctx, s.db, slug)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found", map[string]any{"slug": slug})
+		return utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+
+	subscriber, err := s.subscriberRepo.FindByUnsubscribeToken(ctx, s.db, page.ID, token)
+	if err != nil {
+		utils.Warn(ctx, "Unsubscribe token not found", nil)
+		return utils.NewAppError(http.StatusNotFound, utils.InvalidToken, "Invalid or expired token", err)
+	}
+
+	if err := s.subscriberRepo.Delete(ctx, s.db, subscriber); err != nil {
+		utils.Error(ctx, "Failed to unsubscribe", map[string]any{"subscriber_id": subscriber.PublicID, "err": err.Error()})
+		return utils.InternalServerError("Error unsubscribing", err)
+	}
+
+	utils.Info(ctx, "Subscriber unsubscribed", map[string]any{"subscriber_id": subscriber.PublicID})
+	return nil
+}
+
+func (s *subscriberService) ListByStatusPage(ctx context.Context, userID uint, pageID uuid.UUID) ([]SubscriberResponse, *utils.AppError) {
+	page, err := s.pageRepo.FindByPublicID(ctx, s.db, userID, pageID)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found", map[string]any{"status_page_id": pageID})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+
+	subscribers, err := s.subscriberRepo.ListByStatusPageID(ctx, s.db, page.ID, false)
+	if err != nil {
+		utils.Error(ctx, "Failed to list subscribers", map[string]any{"status_page_id": pageID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error listing subscribers", err)
+	}
+
+	responses := []SubscriberResponse{}
+	for _, subscriber := range subscribers {
+		responses = append(responses, SubscriberResponse{
+			ID:          subscriber.PublicID,
+			Email:       subscriber.Email,
+			Confirmed:   subscriber.Confirmed,
+			ConfirmedAt: subscriber.ConfirmedAt,
+			CreatedAt:   subscriber.CreatedAt,
+		})
+	}
+	return responses, nil
+}
+
+func (s *subscriberService) Remove(ctx context.Context, userID uint, pageID, subscriberID uuid.UUID) *utils.AppError {
+	page, err := s.pageRepo.FindByPublicID(ctx, s.db, userID, pageID)
+	if err != nil {
+		utils.Warn(ctx, "Status page not found", map[string]any{"status_page_id": pageID})
+		return utils.NewAppError(http.StatusNotFound, utils.NotFound, "Status page not found", err)
+	}
+
+	subscriber, err := s.subscriberRepo.FindByPublicID(ctx, s.db, page.ID, subscriberID)
+	if err != nil {
+		utils.Warn(ctx, "Subscriber not found", map[string]any{"subscriber_id": subscriberID})
+		return utils.NewAppError(http.StatusNotFound, utils.NotFound, "Subscriber not found", err)
+	}
+
+	if err := s.subscriberRepo.Delete(ctx, s.db, subscriber); err != nil {
+		utils.Error(ctx, "Failed to remove subscriber", map[string]any{"subscriber_id": subscriberID, "err": err.Error()})
+		return utils.InternalServerError("Error removing subscriber", err)
+	}
+
+	utils.Info(ctx, "Subscriber removed", map[string]any{"subscriber_id": subscriberID})
+	return nil
+}
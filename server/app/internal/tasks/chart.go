@@ -0,0 +1,71 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"uptimatic/internal/adapters/chart"
+	"uptimatic/internal/adapters/email"
+	"uptimatic/internal/models"
+)
+
+// Inline image names referenced by the email templates as cid:<name>.
+const (
+	ResponseTimeChart = "response_time.png"
+	UptimeChart       = "uptime.png"
+)
+
+// alertChartWindow is how far back the charts in down and up alerts reach.
+const alertChartWindow = 24 * time.Hour
+
+// RenderCharts draws the response time line and uptime bars embedded in emails, with one point per
+// bucket in starts. Buckets missing from stats or spent entirely in maintenance are left empty.
+func RenderCharts(starts []time.Time, stats []models.UptimeStat) ([]email.InlineImage, error) {
+	statsByStart := map[int64]models.UptimeStat{}
+	for _, stat := range stats {
+		statsByStart[stat.BucketStart.Unix()] = stat
+	}
+
+	responseTimes := make([]*float64, len(starts))
+	uptimes := make([]*float64, len(starts))
+	for i, start := range starts {
+		stat, ok := statsByStart[start.Unix()]
+		if !ok || stat.TotalChecks == stat.MaintenanceChecks {
+			continue
+		}
+		uptime := stat.UptimePercent
+		uptimes[i] = &uptime
+		responseTimes[i] = stat.AvgResponseTime
+	}
+
+	responseTime, err := chart.ResponseTime(responseTimes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render response time chart: %w", err)
+	}
+	uptime, err := chart.UptimeBars(uptimes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render uptime chart: %w", err)
+	}
+
+	return []email.InlineImage{
+		{Name: ResponseTimeChart, ContentType: chart.ContentType, Data: responseTime},
+		{Name: UptimeChart, ContentType: chart.ContentType, Data: uptime},
+	}, nil
+}
+
+// alertCharts renders hourly charts of the monitor's last 24 hours up to now.
+func (h *TaskHandler) alertCharts(ctx context.Context, urlID uint, now time.Time) ([]email.InlineImage, error) {
+	end := now.UTC().Truncate(time.Hour).Add(time.Hour)
+	start := end.Add(-alertChartWindow)
+
+	stats, err := h.logRepo.GetUptimeStats(ctx, h.pgsql, urlID, "1h", "UTC", start, end, map[string]bool{"avg": true}, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get uptime stats: %w", err)
+	}
+
+	starts := []time.Time{}
+	for t := start; t.Before(end); t = t.Add(time.Hour) {
+		starts = append(starts, t)
+	}
+	return RenderCharts(starts, stats)
+}
@@ -9,26 +9,36 @@ import (
 	"strconv"
 	"time"
 	"uptimatic/internal/adapters/email"
+	"uptimatic/internal/archive"
 	"uptimatic/internal/config"
+	"uptimatic/internal/metrics"
 	"uptimatic/internal/models"
 	"uptimatic/internal/url"
 	"uptimatic/internal/utils"
 
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"gorm.io/gorm"
 )
 
 type TaskHandler struct {
-	cfg      *config.Config
-	pgsql    *gorm.DB
-	client   *asynq.Client
-	mailTask *email.EmailTask
-	urlRepo  url.UrlRepository
-	logRepo  url.StatusLogRepository
+	cfg             *config.Config
+	pgsql           *gorm.DB
+	client          *asynq.Client
+	mailTask        *email.EmailTask
+	urlRepo         url.UrlRepository
+	logRepo         url.StatusLogRepository
+	maintenanceRepo url.MaintenanceRepository
+	incidentRepo    url.IncidentRepository
+	rollupRepo      url.RollupRepository
+	partitionRepo   url.StatusLogPartitionRepository
+	slaAlertRepo    url.SLAAlertRepository
+	archiveService  archive.ArchiveService
+	metricsCache    url.MonitorMetricsCache
 }
 
-func NewTaskHandler(cfg *config.Config, pgsql *gorm.DB, client *asynq.Client, mailTask *email.EmailTask, urlRepo url.UrlRepository, logRepo url.StatusLogRepository) *TaskHandler {
-	return &TaskHandler{cfg, pgsql, client, mailTask, urlRepo, logRepo}
+func NewTaskHandler(cfg *config.Config, pgsql *gorm.DB, client *asynq.Client, mailTask *email.EmailTask, urlRepo url.UrlRepository, logRepo url.StatusLogRepository, maintenanceRepo url.MaintenanceRepository, incidentRepo url.IncidentRepository, rollupRepo url.RollupRepository, partitionRepo url.StatusLogPartitionRepository, slaAlertRepo url.SLAAlertRepository, archiveService archive.ArchiveService, metricsCache url.MonitorMetricsCache) *TaskHandler {
+	return &TaskHandler{cfg, pgsql, client, mailTask, urlRepo, logRepo, maintenanceRepo, incidentRepo, rollupRepo, partitionRepo, slaAlertRepo, archiveService, metricsCache}
 }
 
 func (h *TaskHandler) SendEmailHandler(ctx context.Context, t *asynq.Task) error {
@@ -44,7 +54,8 @@ func (h *TaskHandler) SendEmailHandler(ctx context.Context, t *asynq.Task) error
 		"type":    payload.Type,
 	})
 
-	if err := h.mailTask.SendEmail(ctx, payload.To, payload.Subject, payload.Type, payload.Data); err != nil {
+	if err := h.mailTask.SendEmail(ctx, payload.To, payload.Subject, payload.Type, payload.Data, payload.Images...); err != nil {
+		metrics.EmailsSent.WithLabelValues(string(payload.Type), metrics.ResultFailure).Inc()
 		utils.Error(ctx, "Failed to send email", map[string]any{
 			"to":    payload.To,
 			"error": err.Error(),
@@ -52,6 +63,7 @@ func (h *TaskHandler) SendEmailHandler(ctx context.Context, t *asynq.Task) error
 		return err
 	}
 
+	metrics.EmailsSent.WithLabelValues(string(payload.Type), metrics.ResultSuccess).Inc()
 	utils.Info(ctx, "Email sent successfully", map[string]any{
 		"to":      payload.To,
 		"subject": payload.Subject,
@@ -59,19 +71,19 @@ func (h *TaskHandler) SendEmailHandler(ctx context.Context, t *asynq.Task) error
 	return nil
 }
 
-func (h *TaskHandler) enqueueEmail(to, subject string, emailType email.EmailType, data map[string]any) error {
+func (h *TaskHandler) enqueueEmail(ctx context.Context, to, subject string, emailType email.EmailType, data map[string]any, images ...email.InlineImage) error {
 	emailPayload, err := json.Marshal(email.EmailPayload{
 		To:      to,
 		Subject: subject,
 		Type:    emailType,
 		Data:    data,
+		Images:  images,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to marshal email payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskSendEmail, emailPayload)
-	if _, err := h.client.Enqueue(task); err != nil {
+	if _, err := Enqueue(ctx, h.client, TaskSendEmail, emailPayload); err != nil {
 		return fmt.Errorf("failed to enqueue email task: %w", err)
 	}
 	return nil
@@ -90,12 +102,13 @@ func (h *TaskHandler) CheckUptimeHandler(ctx context.Context, t *asynq.Task) err
 		"label":  payload.Label,
 	})
 
-	lastLog, err := h.logRepo.GetLastLogByURLID(ctx, h.pgsql, payload.ID)
+	lastLog, err := h.logRepo.GetLastEffectiveLogByURLID(ctx, h.pgsql, payload.ID)
 	var lastStatus int64
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			utils.Debug(ctx, "No previous log found, treating as first check", nil)
-			lastStatus = 0
+			// Status 0 now means no response, so the first check compares against a status no check has.
+			lastStatus = -1
 		} else {
 			utils.Error(ctx, "Failed to get last log", map[string]any{"error": err.Error()})
 			return fmt.Errorf("failed to get last log: %w", err)
@@ -108,7 +121,14 @@ func (h *TaskHandler) CheckUptimeHandler(ctx context.Context, t *asynq.Task) err
 		}
 	}
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	windows, err := h.maintenanceRepo.ListActiveByURLIDs(ctx, h.pgsql, []uint{payload.ID})
+	if err != nil {
+		utils.Error(ctx, "Failed to get maintenance windows", map[string]any{"url_id": payload.ID, "error": err.Error()})
+		return fmt.Errorf("failed to get maintenance windows: %w", err)
+	}
+	inMaintenance := url.MaintenanceURLIDs(windows, time.Now())[payload.ID]
+
+	client := &http.Client{Timeout: 30 * time.Second, Transport: probeTransport}
 	ctxReq, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
@@ -118,25 +138,42 @@ func (h *TaskHandler) CheckUptimeHandler(ctx context.Context, t *asynq.Task) err
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	start := time.Now()
-	resp, err := client.Do(req)
-	if err != nil {
-		utils.Error(ctx, "HTTP request failed", map[string]any{"url": payload.URL, "error": err.Error()})
-		return fmt.Errorf("failed to check URL %s: %w", payload.URL, err)
+	// A check that gets no response is still recorded, as a down check classified by what failed,
+	// so outages where the server cannot even be reached count toward uptime and incidents.
+	resp, timings, probeErr := doProbe(client, req)
+	statusCode := url.StatusNoResponse
+	errorKind := ""
+	if probeErr != nil {
+		errorKind = probeErrorKind(probeErr)
+		utils.Warn(ctx, "HTTP request failed", map[string]any{"url": payload.URL, "error_kind": errorKind, "error": probeErr.Error()})
+	} else {
+		statusCode = resp.StatusCode
+		errorKind = url.ErrorKind(statusCode)
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			utils.Error(ctx, "Failed to close response body", map[string]any{"error": err.Error()})
-		}
-	}()
+	down := url.IsDown(statusCode)
 
-	duration := time.Since(start)
+	dependent := false
+	if down {
+		dependent, err = h.isParentDown(ctx, payload.ID)
+		if err != nil {
+			utils.Error(ctx, "Failed to check parent URLs", map[string]any{"url_id": payload.ID, "error": err.Error()})
+			return fmt.Errorf("failed to check parent URLs: %w", err)
+		}
+	}
 
 	log := models.StatusLog{
-		URLID:        payload.ID,
-		Status:       strconv.Itoa(resp.StatusCode),
-		ResponseTime: int64(duration.Milliseconds()),
-		CheckedAt:    time.Now().UTC(),
+		URLID:         payload.ID,
+		Status:        strconv.Itoa(statusCode),
+		ResponseTime:  timings.ResponseTime.Milliseconds(),
+		InMaintenance: inMaintenance,
+		Dependent:     dependent,
+		CheckedAt:     time.Now().UTC(),
+	}
+	if probeErr == nil {
+		timings.apply(&log)
+	}
+	if errorKind != "" {
+		log.ErrorKind = &errorKind
 	}
 
 	if err := h.logRepo.Create(ctx, h.pgsql, &log); err != nil {
@@ -144,50 +181,91 @@ func (h *TaskHandler) CheckUptimeHandler(ctx context.Context, t *asynq.Task) err
 		return fmt.Errorf("failed to create status log: %w", err)
 	}
 
+	check := &url.MonitorCheck{
+		Up:           !down,
+		StatusCode:   statusCode,
+		ResponseTime: log.ResponseTime,
+		CheckedAt:    log.CheckedAt,
+	}
+	if probeErr != nil {
+		metrics.ChecksTotal.WithLabelValues(metrics.CheckError).Inc()
+	} else {
+		metrics.ChecksTotal.WithLabelValues(checkState(down, inMaintenance, dependent)).Inc()
+		check.CertExpiry = certExpiry(resp)
+	}
+	h.cacheCheck(ctx, payload.ID, check)
+
 	utils.Debug(ctx, "URL checked result", map[string]any{
-		"url":           payload.URL,
-		"status":        log.Status,
-		"response_time": log.ResponseTime,
+		"url":            payload.URL,
+		"status":         log.Status,
+		"error_kind":     errorKind,
+		"response_time":  log.ResponseTime,
+		"in_maintenance": log.InMaintenance,
+		"dependent":      log.Dependent,
 	})
 
-	const downThreshold = 400
-	if resp.StatusCode != int(lastStatus) {
+	if err := h.trackIncident(ctx, &log, down); err != nil {
+		utils.Error(ctx, "Failed to track incident", map[string]any{"url_id": payload.ID, "error": err.Error()})
+		return fmt.Errorf("failed to track incident: %w", err)
+	}
+
+	if inMaintenance {
+		utils.Info(ctx, "URL is in maintenance, notification suppressed", map[string]any{
+			"url":    payload.URL,
+			"status": statusCode,
+		})
+	} else if dependent {
+		utils.Info(ctx, "Parent URL is down, notification suppressed", map[string]any{
+			"url":    payload.URL,
+			"status": statusCode,
+		})
+	} else if statusCode != int(lastStatus) {
 		loc, _ := time.LoadLocation("Asia/Jakarta")
 
+		status := log.Status
+		if probeErr != nil {
+			status = errorKind
+		}
 		data := map[string]any{
 			"LogoURL":      fmt.Sprintf("%s://%s/icon.png", h.cfg.AppScheme, h.cfg.AppDomain),
 			"Label":        payload.Label,
 			"URL":          payload.URL,
-			"Status":       log.Status,
+			"Status":       status,
 			"ResponseTime": log.ResponseTime,
 			"CheckedAt":    log.CheckedAt.In(loc).Format("2006-01-02 15:04:05"),
 		}
 
-		if resp.StatusCode >= downThreshold {
+		// A chart failure should never hold back the alert itself.
+		images, err := h.alertCharts(ctx, payload.ID, log.CheckedAt)
+		if err != nil {
+			utils.Warn(ctx, "Failed to render alert charts", map[string]any{"url_id": payload.ID, "error": err.Error()})
+		}
+		data["Charts"] = len(images) > 0
+
+		if down {
 			utils.Warn(ctx, "URL is down, sending notification", map[string]any{
 				"url":    payload.URL,
-				"status": resp.StatusCode,
+				"status": statusCode,
 			})
 
-			if err := h.enqueueEmail(payload.User.Email, "Uptime Alert - Website Down", email.EmailDown, data); err != nil {
+			if err := h.enqueueEmail(ctx, payload.User.Email, "Uptime Alert - Website Down", email.EmailDown, data, images...); err != nil {
 				utils.Error(ctx, "Failed to enqueue down email", map[string]any{"error": err.Error()})
 				return fmt.Errorf("failed to enqueue down email: %w", err)
 			}
 		} else {
 			utils.Info(ctx, "URL is up, sending notification", map[string]any{
 				"url":    payload.URL,
-				"status": resp.StatusCode,
+				"status": statusCode,
 			})
 
-			if err := h.enqueueEmail(payload.User.Email, "Uptime Alert - Website Up", email.EmailUp, data); err != nil {
+			if err := h.enqueueEmail(ctx, payload.User.Email, "Uptime Alert - Website Up", email.EmailUp, data, images...); err != nil {
 				utils.Error(ctx, "Failed to enqueue up email", map[string]any{"error": err.Error()})
 				return fmt.Errorf("failed to enqueue up email: %w", err)
 			}
 		}
 	}
 
-	payload.LastChecked = &log.CheckedAt
-	if err := h.urlRepo.Update(ctx, h.pgsql, &payload); err != nil {
+	if err := h.urlRepo.UpdateLastChecked(ctx, h.pgsql, payload.ID, log.CheckedAt); err != nil {
 		utils.Error(ctx, "Failed to update URL last checked", map[string]any{
 			"url_id": payload.ID,
 			"error":  err.Error(),
@@ -203,6 +281,81 @@ func (h *TaskHandler) CheckUptimeHandler(ctx context.Context, t *asynq.Task) err
 	return nil
 }
 
+// trackIncident opens an incident on the first alertable failure and resolves it on recovery.
+func (h *TaskHandler) trackIncident(ctx context.Context, log *models.StatusLog, down bool) error {
+	open, err := h.incidentRepo.FindOpenByURLID(ctx, h.pgsql, log.URLID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if !down {
+		if open == nil {
+			return nil
+		}
+		utils.Info(ctx, "Resolving incident", map[string]any{"url_id": log.URLID, "incident_id": open.PublicID})
+		return h.incidentRepo.Resolve(ctx, h.pgsql, open, log.CheckedAt)
+	}
+
+	if open != nil || log.InMaintenance || log.Dependent {
+		return nil
+	}
+
+	status, err := strconv.Atoi(log.Status)
+	if err != nil {
+		return err
+	}
+
+	incident := &models.Incident{
+		PublicID:   uuid.New(),
+		URLID:      log.URLID,
+		StatusCode: status,
+		StartedAt:  log.CheckedAt,
+	}
+	utils.Info(ctx, "Opening incident", map[string]any{"url_id": log.URLID, "incident_id": incident.PublicID})
+	return h.incidentRepo.Create(ctx, h.pgsql, incident)
+}
+
+// checkState is the state a check is counted under in the checks metric.
+func checkState(down, inMaintenance, dependent bool) string {
+	switch {
+	case inMaintenance:
+		return metrics.CheckMaintenance
+	case dependent:
+		return metrics.CheckDependent
+	case down:
+		return metrics.CheckDown
+	default:
+		return metrics.CheckUp
+	}
+}
+
+// isParentDown reports whether any monitor the URL depends on last reported a failure.
+func (h *TaskHandler) isParentDown(ctx context.Context, urlID uint) (bool, error) {
+	parentIDs, err := h.urlRepo.ListParentIDs(ctx, h.pgsql, urlID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, parentID := range parentIDs {
+		parentLog, err := h.logRepo.GetLastLogByURLID(ctx, h.pgsql, parentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return false, err
+		}
+
+		status, err := strconv.Atoi(parentLog.Status)
+		if err != nil {
+			return false, err
+		}
+		if url.IsDown(status) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (h *TaskHandler) ValidateUptimeHandler(ctx context.Context, t *asynq.Task) error {
 	utils.Info(ctx, "Running uptime validation task", nil)
 
@@ -225,8 +378,7 @@ func (h *TaskHandler) ValidateUptimeHandler(ctx context.Context, t *asynq.Task)
 				continue
 			}
 
-			task := asynq.NewTask(TaskCheckUptime, payload)
-			if _, err := h.client.Enqueue(task); err != nil {
+			if _, err := Enqueue(ctx, h.client, TaskCheckUptime, payload); err != nil {
 				utils.Error(ctx, "Failed to enqueue first uptime check task", map[string]any{"url": url.URL, "error": err.Error()})
 				continue
 			}
@@ -247,8 +399,7 @@ func (h *TaskHandler) ValidateUptimeHandler(ctx context.Context, t *asynq.Task)
 				continue
 			}
 
-			task := asynq.NewTask(TaskCheckUptime, payload)
-			if _, err := h.client.Enqueue(task); err != nil {
+			if _, err := Enqueue(ctx, h.client, TaskCheckUptime, payload); err != nil {
 				utils.Error(ctx, "Failed to enqueue uptime check", map[string]any{"url": url.URL, "error": err.Error()})
 				continue
 			}
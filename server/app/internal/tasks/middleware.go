@@ -2,19 +2,39 @@ package tasks
 
 import (
 	"context"
+	"time"
+	"uptimatic/internal/health"
+	"uptimatic/internal/metrics"
+	"uptimatic/internal/tracing"
 	"uptimatic/internal/utils"
 
 	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func MiddlewareHandler(handler func(context.Context, *asynq.Task) error) asynq.HandlerFunc {
 	return func(ctx context.Context, t *asynq.Task) error {
-		ctx = utils.WithTraceID(ctx)
+		ctx, span := tracing.Tracer().Start(tracing.ExtractPayload(ctx, t.Payload()), "task "+t.Type(),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(semconv.MessagingSystemKey.String("asynq"), semconv.MessagingDestinationName(t.Type())),
+		)
+		defer span.End()
+
 		utils.Debug(ctx, "Task started", map[string]any{"type": t.Type()})
+		start := time.Now()
 		err := handler(ctx, t)
+		metrics.TaskDuration.WithLabelValues(t.Type()).Observe(time.Since(start).Seconds())
 		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			metrics.TasksProcessed.WithLabelValues(t.Type(), metrics.ResultFailure).Inc()
 			utils.Error(ctx, "Task failed", map[string]any{"error": err.Error(), "type": t.Type()})
 		} else {
+			metrics.TasksProcessed.WithLabelValues(t.Type(), metrics.ResultSuccess).Inc()
+			health.Beat(t.Type())
+			health.Beat(WorkerHeartbeat)
 			utils.Debug(ctx, "Task completed", map[string]any{"type": t.Type()})
 		}
 		return err
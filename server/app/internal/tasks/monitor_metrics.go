@@ -0,0 +1,26 @@
+package tasks
+
+import (
+	"context"
+	"net/http"
+	"time"
+	"uptimatic/internal/url"
+	"uptimatic/internal/utils"
+)
+
+// cacheCheck publishes a check to the monitor exporter. The exporter is best effort, so a cache
+// failure is logged and does not fail the check.
+func (h *TaskHandler) cacheCheck(ctx context.Context, urlID uint, check *url.MonitorCheck) {
+	if err := h.metricsCache.SetCheck(ctx, urlID, check); err != nil {
+		utils.Warn(ctx, "Failed to cache monitor check", map[string]any{"url_id": urlID, "error": err.Error()})
+	}
+}
+
+// certExpiry returns when the certificate presented by the server expires, or nil for plain HTTP.
+func certExpiry(resp *http.Response) *time.Time {
+	if resp.TLS == nil || len(resp.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+	notAfter := resp.TLS.PeerCertificates[0].NotAfter
+	return &notAfter
+}
@@ -0,0 +1,100 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"uptimatic/internal/db"
+	"uptimatic/internal/url"
+	"uptimatic/internal/utils"
+
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+)
+
+// partitionsAhead is how many months past the current one always have a status_logs partition.
+const partitionsAhead = 3
+
+// PartitionStatusLogsHandler creates upcoming monthly status_logs partitions and drops the ones past
+// raw log retention. A partition is only dropped once all of it has been rolled up and archived
+// to the storage bucket. Months that landed in the default partition are archived and pruned the same way.
+func (h *TaskHandler) PartitionStatusLogsHandler(ctx context.Context, t *asynq.Task) error {
+	partitions, err := h.partitionRepo.List(ctx, h.pgsql)
+	if err != nil {
+		return fmt.Errorf("failed to list status log partitions: %w", err)
+	}
+
+	existing := map[string]bool{}
+	for _, partition := range partitions {
+		existing[partition.Name] = true
+	}
+
+	now := time.Now().UTC()
+	for i := 0; i <= partitionsAhead; i++ {
+		month := url.NewStatusLogPartition(now.AddDate(0, i, 0))
+		if existing[month.Name] {
+			continue
+		}
+
+		err := db.WithTransaction(h.pgsql, func(tx *gorm.DB) error {
+			_, err := h.partitionRepo.Create(ctx, tx, month.Start)
+			return err
+		})
+		if err != nil {
+			utils.Error(ctx, "Failed to create status log partition", map[string]any{"partition": month.Name, "error": err.Error()})
+			return fmt.Errorf("failed to create partition %s: %w", month.Name, err)
+		}
+		utils.Info(ctx, "Status log partition created", map[string]any{"partition": month.Name})
+	}
+
+	if h.cfg.RetentionStatusLogDays <= 0 {
+		return nil
+	}
+
+	watermark, err := h.rollupRepo.GetWatermark(ctx, h.pgsql)
+	if err != nil {
+		return fmt.Errorf("failed to get rollup watermark: %w", err)
+	}
+	if watermark == nil {
+		return nil
+	}
+
+	cutoff := now.AddDate(0, 0, -h.cfg.RetentionStatusLogDays)
+	if watermark.Before(cutoff) {
+		cutoff = *watermark
+	}
+
+	for _, partition := range partitions {
+		if partition.End.After(cutoff) {
+			continue
+		}
+		if err := h.archiveService.ArchivePartition(ctx, &partition); err != nil {
+			return fmt.Errorf("failed to archive partition %s: %w", partition.Name, err)
+		}
+		if err := h.partitionRepo.Drop(ctx, h.pgsql, &partition); err != nil {
+			utils.Error(ctx, "Failed to drop status log partition", map[string]any{"partition": partition.Name, "error": err.Error()})
+			return fmt.Errorf("failed to drop partition %s: %w", partition.Name, err)
+		}
+		utils.Info(ctx, "Status log partition dropped", map[string]any{"partition": partition.Name, "cutoff": cutoff})
+	}
+
+	defaults, err := h.partitionRepo.ListDefaultMonths(ctx, h.pgsql)
+	if err != nil {
+		return fmt.Errorf("failed to list default partition months: %w", err)
+	}
+	for _, partition := range defaults {
+		if partition.End.After(cutoff) {
+			continue
+		}
+		if err := h.archiveService.ArchivePartition(ctx, &partition); err != nil {
+			return fmt.Errorf("failed to archive default partition month %s: %w", partition.Name, err)
+		}
+		if err := h.partitionRepo.PruneDefault(ctx, h.pgsql, &partition); err != nil {
+			utils.Error(ctx, "Failed to prune default status log partition", map[string]any{"partition": partition.Name, "error": err.Error()})
+			return fmt.Errorf("failed to prune default partition month %s: %w", partition.Name, err)
+		}
+		utils.Info(ctx, "Default status log partition pruned", map[string]any{"partition": partition.Name, "cutoff": cutoff})
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package tasks
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"time"
+	"uptimatic/internal/models"
+	"uptimatic/internal/tracing"
+	"uptimatic/internal/url"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// probeBodyLimit caps how much of a response body a check downloads to time the transfer. It is
+// kept small since every check of every monitor pays for it.
+const probeBodyLimit = 64 << 10
+
+// probeTransport opens a fresh connection for every check, so each one pays for DNS, connect and
+// TLS the way a new visitor would instead of reusing a pooled connection.
+var probeTransport = func() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = true
+	return transport
+}()
+
+// probeTimings accumulates the phases of a check. Redirects repeat the phases, so each one adds
+// up over every hop, and TTFB runs from the request being sent to the first response byte.
+type probeTimings struct {
+	mu           sync.Mutex
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+
+	// ResponseTime runs until the response headers arrived, which is what response_time has
+	// always measured; the phases below also cover downloading the body.
+	ResponseTime time.Duration
+	DNS          time.Duration
+	Connect      time.Duration
+	TLS          time.Duration
+	TTFB         time.Duration
+	Transfer     time.Duration
+	RemoteIP     string
+	Protocol     string
+	Size         int64
+}
+
+// apply copies the timings into a status log, in the milliseconds the logs are stored in.
+func (p *probeTimings) apply(log *models.StatusLog) {
+	ms := func(d time.Duration) *int64 {
+		v := d.Milliseconds()
+		return &v
+	}
+	log.DNSTime = ms(p.DNS)
+	log.ConnectTime = ms(p.Connect)
+	log.TLSTime = ms(p.TLS)
+	log.TTFBTime = ms(p.TTFB)
+	log.TransferTime = ms(p.Transfer)
+	if p.RemoteIP != "" {
+		log.RemoteIP = &p.RemoteIP
+	}
+	log.Protocol = &p.Protocol
+	log.ResponseSize = &p.Size
+}
+
+// doProbe sends a check request inside a probe span and downloads the body to time the transfer.
+// DNS lookup, connect and TLS handshake get child spans, and the first response byte is recorded
+// as an event on the probe span. The returned response has its body drained and closed. When the
+// request fails the timings still carry how long the check took before giving up.
+func doProbe(client *http.Client, req *http.Request) (*http.Response, *probeTimings, error) {
+	ctx, span := tracing.Tracer().Start(req.Context(), "check_uptime.probe",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.URLFull(req.URL.String()), semconv.HTTPRequestMethodKey.String(req.Method)),
+	)
+	defer span.End()
+
+	timings := &probeTimings{}
+	start := time.Now()
+	resp, err := client.Do(req.WithContext(httptrace.WithClientTrace(ctx, probeTrace(ctx, timings))))
+	responseTime := time.Since(start)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, &probeTimings{ResponseTime: responseTime}, err
+	}
+	defer resp.Body.Close()
+
+	// A body cut short only shortens the transfer time, the check itself already has its status.
+	size, _ := io.Copy(io.Discard, io.LimitReader(resp.Body, probeBodyLimit))
+	if resp.ContentLength > size {
+		size = resp.ContentLength
+	}
+
+	timings.mu.Lock()
+	defer timings.mu.Unlock()
+	if !timings.firstByte.IsZero() {
+		timings.Transfer = time.Since(timings.firstByte)
+	}
+	timings.ResponseTime = responseTime
+	timings.Protocol = resp.Proto
+	timings.Size = size
+
+	span.SetAttributes(
+		semconv.HTTPResponseStatusCode(resp.StatusCode),
+		semconv.HTTPResponseBodySize(int(size)),
+		semconv.NetworkPeerAddress(timings.RemoteIP),
+	)
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, timings, nil
+}
+
+// probeTrace records the phases into timings and starts the phase spans under the probe span in
+// ctx. A dual-stack dial can connect to several addresses at once, so connect spans are tracked
+// per address and only the attempt that succeeds counts toward the connect time.
+func probeTrace(ctx context.Context, timings *probeTimings) *httptrace.ClientTrace {
+	var (
+		dns      trace.Span
+		tlsSpan  trace.Span
+		connects = map[string]trace.Span{}
+	)
+	tracer := tracing.Tracer()
+	mu := &timings.mu
+
+	return &httptrace.ClientTrace{
+		DNSStart: func(info httptrace.DNSStartInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			timings.dnsStart = time.Now()
+			_, dns = tracer.Start(ctx, "dns", trace.WithAttributes(semconv.ServerAddress(info.Host)))
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			timings.DNS += time.Since(timings.dnsStart)
+			endPhase(dns, info.Err)
+			dns = nil
+		},
+		ConnectStart: func(network, addr string) {
+			mu.Lock()
+			defer mu.Unlock()
+			if timings.connectStart.IsZero() {
+				timings.connectStart = time.Now()
+			}
+			_, connects[network+"/"+addr] = tracer.Start(ctx, "connect", trace.WithAttributes(
+				semconv.NetworkTransportKey.String(network),
+				semconv.NetworkPeerAddress(addr),
+			))
+		},
+		ConnectDone: func(network, addr string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil && !timings.connectStart.IsZero() {
+				timings.Connect += time.Since(timings.connectStart)
+				timings.connectStart = time.Time{}
+			}
+			endPhase(connects[network+"/"+addr], err)
+			delete(connects, network+"/"+addr)
+		},
+		TLSHandshakeStart: func() {
+			mu.Lock()
+			defer mu.Unlock()
+			timings.tlsStart = time.Now()
+			_, tlsSpan = tracer.Start(ctx, "tls")
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			timings.TLS += time.Since(timings.tlsStart)
+			if tlsSpan != nil && err == nil {
+				tlsSpan.SetAttributes(semconv.TLSProtocolVersion(strings.TrimPrefix(tls.VersionName(state.Version), "TLS ")))
+			}
+			endPhase(tlsSpan, err)
+			tlsSpan = nil
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			if host, _, err := net.SplitHostPort(info.Conn.RemoteAddr().String()); err == nil {
+				timings.RemoteIP = host
+			}
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			timings.wroteRequest = time.Now()
+		},
+		GotFirstResponseByte: func() {
+			mu.Lock()
+			defer mu.Unlock()
+			timings.firstByte = time.Now()
+			if !timings.wroteRequest.IsZero() {
+				timings.TTFB += timings.firstByte.Sub(timings.wroteRequest)
+			}
+			trace.SpanFromContext(ctx).AddEvent("first_byte")
+		},
+	}
+}
+
+// probeErrorKind classifies a check that failed before a response arrived.
+func probeErrorKind(err error) string {
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	var netErr net.Error
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return url.ErrorKindDNS
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return url.ErrorKindTimeout
+	case errors.As(err, &certErr), errors.As(err, &recordErr), errors.As(err, &alertErr),
+		errors.As(err, &authorityErr), errors.As(err, &hostnameErr), errors.As(err, &invalidErr):
+		return url.ErrorKindTLS
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		return url.ErrorKindConnect
+	default:
+		return url.ErrorKindOther
+	}
+}
+
+func endPhase(span trace.Span, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,97 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"uptimatic/internal/db"
+	"uptimatic/internal/utils"
+
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+)
+
+const (
+	// rollupChunk is how much raw history a single rollup transaction covers.
+	rollupChunk = 24 * time.Hour
+	// rollupMaxChunks caps one run, so catching up on a large backlog is spread over several runs.
+	rollupMaxChunks = 31
+)
+
+// RollupStatsHandler rolls every completed hour since the watermark into the hourly and daily rollups.
+func (h *TaskHandler) RollupStatsHandler(ctx context.Context, t *asynq.Task) error {
+	target := time.Now().UTC().Truncate(time.Hour)
+
+	watermark, err := h.rollupRepo.GetWatermark(ctx, h.pgsql)
+	if err != nil {
+		return fmt.Errorf("failed to get rollup watermark: %w", err)
+	}
+	if watermark == nil {
+		first, err := h.rollupRepo.GetFirstCheckedAt(ctx, h.pgsql)
+		if err != nil {
+			return fmt.Errorf("failed to get first check: %w", err)
+		}
+		if first == nil {
+			first = &target
+		}
+		start := first.UTC().Truncate(time.Hour)
+		watermark = &start
+	}
+
+	var realigned int64
+	err = db.WithTransaction(h.pgsql, func(tx *gorm.DB) error {
+		realigned, err = h.rollupRepo.RealignDaily(ctx, tx)
+		return err
+	})
+	if err != nil {
+		utils.Error(ctx, "Failed to realign daily rollups", map[string]any{"error": err.Error()})
+		return fmt.Errorf("failed to realign daily rollups: %w", err)
+	}
+	if realigned > 0 {
+		utils.Info(ctx, "Daily rollups realigned to a new time zone", map[string]any{"monitors": realigned})
+	}
+
+	from := *watermark
+	for i := 0; watermark.Before(target) && i < rollupMaxChunks; i++ {
+		start := *watermark
+		end := start.Add(rollupChunk)
+		if end.After(target) {
+			end = target
+		}
+
+		err := db.WithTransaction(h.pgsql, func(tx *gorm.DB) error {
+			if err := h.rollupRepo.RollupHourly(ctx, tx, start, end); err != nil {
+				return err
+			}
+			if err := h.rollupRepo.RollupDaily(ctx, tx, start, end); err != nil {
+				return err
+			}
+			return h.rollupRepo.SetWatermark(ctx, tx, end)
+		})
+		if err != nil {
+			utils.Error(ctx, "Failed to roll up status logs", map[string]any{"from": start, "to": end, "error": err.Error()})
+			return fmt.Errorf("failed to roll up status logs: %w", err)
+		}
+		watermark = &end
+	}
+
+	utils.Info(ctx, "Status logs rolled up", map[string]any{"from": from, "to": *watermark, "caught_up": !watermark.Before(target)})
+	return nil
+}
+
+// PruneRollupsHandler drops hourly rollups past their retention; daily rollups are kept for good.
+func (h *TaskHandler) PruneRollupsHandler(ctx context.Context, t *asynq.Task) error {
+	if h.cfg.RetentionHourlyRollupDays <= 0 {
+		return nil
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -h.cfg.RetentionHourlyRollupDays)
+	deleted, err := h.rollupRepo.DeleteHourlyBefore(ctx, h.pgsql, cutoff)
+	if err != nil {
+		utils.Error(ctx, "Failed to prune hourly rollups", map[string]any{"before": cutoff, "error": err.Error()})
+		return fmt.Errorf("failed to prune hourly rollups: %w", err)
+	}
+
+	utils.Info(ctx, "Hourly rollups pruned", map[string]any{"before": cutoff, "deleted": deleted})
+	return nil
+}
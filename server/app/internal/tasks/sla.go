@@ -0,0 +1,120 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"uptimatic/internal/adapters/email"
+	"uptimatic/internal/models"
+	"uptimatic/internal/url"
+	"uptimatic/internal/utils"
+
+	"github.com/hibiken/asynq"
+)
+
+var slaWindowLabels = map[string]string{
+	models.SLAWindowRolling30d:    "30 hari terakhir",
+	models.SLAWindowCalendarMonth: "bulan ini",
+}
+
+// CheckSLAHandler alerts owners when a monitor's error budget consumption crosses one of its thresholds.
+// Each threshold fires once and is re-armed when consumption drops back below it, such as when a new
+// calendar month starts or old downtime leaves the rolling window.
+func (h *TaskHandler) CheckSLAHandler(ctx context.Context, t *asynq.Task) error {
+	urls, err := h.urlRepo.ListWithSLA(ctx, h.pgsql)
+	if err != nil {
+		utils.Error(ctx, "Failed to list URLs with SLA", map[string]any{"error": err.Error()})
+		return fmt.Errorf("failed to list URLs with SLA: %w", err)
+	}
+
+	now := time.Now()
+	for i := range urls {
+		if err := h.checkSLA(ctx, &urls[i], now); err != nil {
+			utils.Error(ctx, "Failed to check SLA", map[string]any{"url_id": urls[i].ID, "error": err.Error()})
+		}
+	}
+
+	utils.Info(ctx, "SLA check completed", map[string]any{"total_urls": len(urls)})
+	return nil
+}
+
+func (h *TaskHandler) checkSLA(ctx context.Context, u *models.URL, now time.Time) error {
+	loc, err := time.LoadLocation(u.User.Timezone)
+	if err != nil {
+		loc, _ = time.LoadLocation(utils.DefaultTimezone)
+	}
+
+	start, end := url.SLAWindowRange(u.SLAWindow, now, loc)
+	summary, err := h.logRepo.GetUptimeSummary(ctx, h.pgsql, u.ID, start.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to get uptime summary: %w", err)
+	}
+	sla := url.NewSLAResponse(u, summary, start, end, now)
+	if err := h.metricsCache.SetSLA(ctx, u.ID, &url.MonitorSLA{
+		RemainingBudgetSeconds: sla.RemainingBudgetSeconds,
+		BudgetConsumedPercent:  sla.BudgetConsumedPercent,
+		UpdatedAt:              now.UTC(),
+	}); err != nil {
+		utils.Warn(ctx, "Failed to cache monitor SLA", map[string]any{"url_id": u.ID, "error": err.Error()})
+	}
+
+	alerted, err := h.slaAlertRepo.ListThresholdsByURLID(ctx, h.pgsql, u.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list SLA alerts: %w", err)
+	}
+
+	configured := map[int64]bool{}
+	for _, threshold := range u.SLAAlertThresholds {
+		configured[threshold] = true
+	}
+	alertedSet := map[int64]bool{}
+	rearmed := []int64{}
+	for _, threshold := range alerted {
+		alertedSet[threshold] = true
+		if !configured[threshold] || sla.BudgetConsumedPercent < float64(threshold) {
+			rearmed = append(rearmed, threshold)
+		}
+	}
+	if err := h.slaAlertRepo.Delete(ctx, h.pgsql, u.ID, rearmed); err != nil {
+		return fmt.Errorf("failed to re-arm SLA alerts: %w", err)
+	}
+
+	crossed := []int64{}
+	for _, threshold := range u.SLAAlertThresholds {
+		if sla.BudgetConsumedPercent >= float64(threshold) && !alertedSet[threshold] {
+			crossed = append(crossed, threshold)
+		}
+	}
+	if len(crossed) == 0 {
+		return nil
+	}
+
+	// Thresholds are sorted, so a sudden outage crossing several of them sends a single alert for the highest.
+	threshold := crossed[len(crossed)-1]
+	utils.Warn(ctx, "SLA error budget threshold crossed, sending notification", map[string]any{
+		"url_id":    u.ID,
+		"threshold": threshold,
+		"consumed":  sla.BudgetConsumedPercent,
+	})
+
+	subject := fmt.Sprintf("SLA Alert - %d%% of error budget used", threshold)
+	err = h.enqueueEmail(ctx, u.User.Email, subject, email.EmailSLABudget, map[string]any{
+		"LogoURL":         fmt.Sprintf("%s://%s/icon.png", h.cfg.AppScheme, h.cfg.AppDomain),
+		"Label":           u.Label,
+		"URL":             u.URL,
+		"Threshold":       threshold,
+		"Target":          fmt.Sprintf("%g%%", sla.Target),
+		"AchievedUptime":  fmt.Sprintf("%.2f%%", sla.AchievedUptime),
+		"Window":          slaWindowLabels[sla.Window],
+		"AllowedDowntime": (time.Duration(sla.AllowedDowntimeSeconds) * time.Second).String(),
+		"Downtime":        (time.Duration(sla.DowntimeSeconds) * time.Second).String(),
+		"RemainingBudget": (time.Duration(sla.RemainingBudgetSeconds) * time.Second).String(),
+		"BudgetConsumed":  fmt.Sprintf("%.2f%%", sla.BudgetConsumedPercent),
+		"BurnRate":        fmt.Sprintf("%.2f", sla.BurnRate),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue SLA email: %w", err)
+	}
+
+	return h.slaAlertRepo.Create(ctx, h.pgsql, u.ID, crossed)
+}
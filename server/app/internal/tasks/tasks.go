@@ -1,31 +1,64 @@
 package tasks
 
 import (
+	"context"
 	"encoding/json"
 	"uptimatic/internal/adapters/email"
+	"uptimatic/internal/tracing"
 
 	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	TaskSendEmail      = "send_email"
-	TaskValidateUptime = "validate_uptime"
-	TaskCheckUptime    = "check_uptime"
+	TaskSendEmail       = "send_email"
+	TaskValidateUptime  = "validate_uptime"
+	TaskCheckUptime     = "check_uptime"
+	TaskExport          = "export"
+	TaskRollupStats     = "rollup_stats"
+	TaskPartitionLogs   = "partition_status_logs"
+	TaskPruneRollups    = "prune_rollups"
+	TaskCheckSLA        = "check_sla"
+	TaskScheduleReports = "schedule_reports"
+	TaskSendReport      = "send_report"
 )
 
-func EnqueueEmail(client *asynq.Client, to, subject string, mailType email.EmailType, data map[string]any) error {
+// WorkerHeartbeat beats whenever this worker finishes any task or its asynq server reaches Redis, so
+// every replica stays live however the queue spreads tasks between them.
+const WorkerHeartbeat = "worker"
+
+// Enqueue queues a task under a producer span and stores the trace context in its payload, so the
+// worker continues the trace of whoever queued it.
+func Enqueue(ctx context.Context, client *asynq.Client, typename string, payload []byte, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "enqueue "+typename,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(semconv.MessagingSystemKey.String("asynq"), semconv.MessagingDestinationName(typename)),
+	)
+	defer span.End()
+
+	info, err := client.Enqueue(asynq.NewTask(typename, tracing.InjectPayload(ctx, payload)), opts...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return info, err
+}
+
+func EnqueueEmail(ctx context.Context, client *asynq.Client, to, subject string, mailType email.EmailType, data map[string]any, images ...email.InlineImage) error {
 	payload, err := json.Marshal(email.EmailPayload{
 		To:      to,
 		Subject: subject,
 		Type:    mailType,
 		Data:    data,
+		Images:  images,
 	})
 	if err != nil {
 		return err
 	}
 
-	task := asynq.NewTask(TaskSendEmail, payload)
-	_, err = client.Enqueue(task,
+	_, err = Enqueue(ctx, client, TaskSendEmail, payload,
 		asynq.MaxRetry(3),
 		asynq.ProcessIn(0),
 	)
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// payloadKey holds the trace context inside JSON task payloads. asynq tasks have no headers, and
+// task handlers ignore unknown fields, so it rides along without touching the payload types.
+const payloadKey = "_trace"
+
+// InjectPayload adds the trace context of ctx to a JSON object payload. Other payloads, such as
+// the empty ones of scheduled tasks, are returned unchanged.
+func InjectPayload(ctx context.Context, payload []byte) []byte {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return payload
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil || fields == nil {
+		return payload
+	}
+	trace, err := json.Marshal(carrier)
+	if err != nil {
+		return payload
+	}
+	fields[payloadKey] = trace
+
+	injected, err := json.Marshal(fields)
+	if err != nil {
+		return payload
+	}
+	return injected
+}
+
+// ExtractPayload returns ctx carrying the trace context stored by InjectPayload, if any.
+func ExtractPayload(ctx context.Context, payload []byte) context.Context {
+	var fields struct {
+		Trace propagation.MapCarrier `json:"_trace"`
+	}
+	if err := json.Unmarshal(payload, &fields); err != nil || len(fields.Trace) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, fields.Trace)
+}
@@ -0,0 +1,113 @@
+package url
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+const (
+	BadgeStatus       = "status"
+	BadgeUptime24h    = "uptime-24h"
+	BadgeUptime7d     = "uptime-7d"
+	BadgeUptime30d    = "uptime-30d"
+	BadgeUptime90d    = "uptime-90d"
+	BadgeResponseTime = "response-time"
+)
+
+var BadgeKinds = []string{BadgeStatus, BadgeUptime24h, BadgeUptime7d, BadgeUptime30d, BadgeUptime90d, BadgeResponseTime}
+
+// badgeColors maps the shields.io named colors we emit to the hex used in our own SVGs.
+var badgeColors = map[string]string{
+	"brightgreen": "#4c1",
+	"green":       "#97ca00",
+	"yellowgreen": "#a4a61d",
+	"yellow":      "#dfb317",
+	"orange":      "#fe7d37",
+	"red":         "#e05d44",
+	"blue":        "#007ec6",
+	"lightgrey":   "#9f9f9f",
+}
+
+func uptimeColor(percent float64) string {
+	switch {
+	case percent >= 99.9:
+		return "brightgreen"
+	case percent >= 99:
+		return "green"
+	case percent >= 97:
+		return "yellowgreen"
+	case percent >= 95:
+		return "yellow"
+	case percent >= 90:
+		return "orange"
+	default:
+		return "red"
+	}
+}
+
+func responseTimeColor(ms float64) string {
+	switch {
+	case ms < 300:
+		return "brightgreen"
+	case ms < 800:
+		return "yellow"
+	case ms < 2000:
+		return "orange"
+	default:
+		return "red"
+	}
+}
+
+// textWidth approximates the rendered width of s in 11px Verdana, which is what the badge uses.
+func textWidth(s string) int {
+	width := 0.0
+	for _, r := range s {
+		switch {
+		case strings.ContainsRune("ijlI.,:;'|!", r):
+			width += 3.5
+		case strings.ContainsRune("frt() ", r):
+			width += 4.5
+		case strings.ContainsRune("mwMW%", r):
+			width += 10
+		case r >= 'A' && r <= 'Z':
+			width += 7.5
+		default:
+			width += 6.5
+		}
+	}
+	return int(width + 0.5)
+}
+
+// RenderBadge draws a flat shields-style badge with the label on the left and message on the right.
+func RenderBadge(badge *ShieldsResponse) []byte {
+	color, ok := badgeColors[badge.Color]
+	if !ok {
+		color = badgeColors["lightgrey"]
+	}
+
+	label := html.EscapeString(badge.Label)
+	message := html.EscapeString(badge.Message)
+	labelWidth := textWidth(badge.Label) + 10
+	messageWidth := textWidth(badge.Message) + 10
+	width := labelWidth + messageWidth
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`, width, label, message)
+	fmt.Fprintf(&b, `<title>%s: %s</title>`, label, message)
+	b.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	fmt.Fprintf(&b, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, width)
+	b.WriteString(`<g clip-path="url(#r)">`)
+	fmt.Fprintf(&b, `<rect width="%d" height="20" fill="#555"/>`, labelWidth)
+	fmt.Fprintf(&b, `<rect x="%d" width="%d" height="20" fill="%s"/>`, labelWidth, messageWidth, color)
+	fmt.Fprintf(&b, `<rect width="%d" height="20" fill="url(#s)"/>`, width)
+	b.WriteString(`</g>`)
+	b.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	fmt.Fprintf(&b, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text>`, labelWidth/2, label)
+	fmt.Fprintf(&b, `<text x="%d" y="14">%s</text>`, labelWidth/2, label)
+	fmt.Fprintf(&b, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text>`, labelWidth+messageWidth/2, message)
+	fmt.Fprintf(&b, `<text x="%d" y="14">%s</text>`, labelWidth+messageWidth/2, message)
+	b.WriteString(`</g></svg>`)
+
+	return []byte(b.String())
+}
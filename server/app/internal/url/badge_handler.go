@@ -0,0 +1,59 @@
+package url
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"uptimatic/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BadgeHandler interface {
+	SVGHandler(c *gin.Context)
+	ShieldsHandler(c *gin.Context)
+}
+
+type badgeHandler struct {
+	badgeService BadgeService
+}
+
+func NewBadgeHandler(badgeService BadgeService) BadgeHandler {
+	return &badgeHandler{badgeService}
+}
+
+func (h *badgeHandler) SVGHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	badge, errSvc := h.badgeService.GetBadge(c.Request.Context(), id, strings.TrimSuffix(c.Param("kind"), ".svg"))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", badge.CacheSeconds))
+	c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", RenderBadge(badge))
+}
+
+// ShieldsHandler serves the endpoint JSON that shields.io renders via img.shields.io/endpoint.
+func (h *badgeHandler) ShieldsHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	badge, errSvc := h.badgeService.GetBadge(c.Request.Context(), id, c.Param("kind"))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", badge.CacheSeconds))
+	c.JSON(http.StatusOK, badge)
+}
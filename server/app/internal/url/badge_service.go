@@ -0,0 +1,157 @@
+package url
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+	"uptimatic/internal/models"
+	"uptimatic/internal/utils"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const badgeCacheTTL = 5 * time.Minute
+
+var badgeWindows = map[string]time.Duration{
+	BadgeUptime24h:    24 * time.Hour,
+	BadgeUptime7d:     7 * 24 * time.Hour,
+	BadgeUptime30d:    30 * 24 * time.Hour,
+	BadgeUptime90d:    90 * 24 * time.Hour,
+	BadgeResponseTime: 24 * time.Hour,
+}
+
+type BadgeService interface {
+	GetBadge(ctx context.Context, urlID uuid.UUID, kind string) (*ShieldsResponse, *utils.AppError)
+}
+
+type badgeService struct {
+	db              *gorm.DB
+	redis           *redis.Client
+	urlRepo         UrlRepository
+	statusLogRepo   StatusLogRepository
+	maintenanceRepo MaintenanceRepository
+}
+
+func NewBadgeService(db *gorm.DB, redis *redis.Client, urlRepo UrlRepository, statusLogRepo StatusLogRepository, maintenanceRepo MaintenanceRepository) BadgeService {
+	return &badgeService{db, redis, urlRepo, statusLogRepo, maintenanceRepo}
+}
+
+func (s *badgeService) GetBadge(ctx context.Context, urlID uuid.UUID, kind string) (*ShieldsResponse, *utils.AppError) {
+	if !slices.Contains(BadgeKinds, kind) {
+		utils.Warn(ctx, "Invalid badge kind", map[string]any{"kind": kind})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Badge not found", nil)
+	}
+
+	key := utils.GetBadgeKey(urlID.String(), kind)
+	if cached, err := s.redis.Get(ctx, key).Bytes(); err == nil {
+		var badge ShieldsResponse
+		if err := json.Unmarshal(cached, &badge); err == nil {
+			return &badge, nil
+		}
+	}
+
+	url, err := s.urlRepo.FindByPublicID(ctx, s.db, urlID)
+	if err != nil || !url.BadgeVisible {
+		utils.Warn(ctx, "Badge requested for hidden or missing URL", map[string]any{"url_id": urlID})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Badge not found", err)
+	}
+
+	var badge *ShieldsResponse
+	if kind == BadgeStatus {
+		badge, err = s.statusBadge(ctx, url)
+	} else {
+		badge, err = s.summaryBadge(ctx, url, kind)
+	}
+	if err != nil {
+		utils.Error(ctx, "Failed to build badge", map[string]any{"url_id": urlID, "kind": kind, "err": err.Error()})
+		return nil, utils.InternalServerError("Error building badge", err)
+	}
+
+	if data, err := json.Marshal(badge); err == nil {
+		if err := s.redis.Set(ctx, key, data, badgeCacheTTL).Err(); err != nil {
+			utils.Warn(ctx, "Failed to cache badge", map[string]any{"url_id": urlID, "kind": kind, "err": err.Error()})
+		}
+	}
+
+	return badge, nil
+}
+
+func (s *badgeService) statusBadge(ctx context.Context, url *models.URL) (*ShieldsResponse, error) {
+	badge := newShieldsResponse("status", "unknown", "lightgrey")
+	if !url.Active {
+		badge.Message = "paused"
+		return badge, nil
+	}
+
+	windows, err := s.maintenanceRepo.ListActiveByURLIDs(ctx, s.db, []uint{url.ID})
+	if err != nil {
+		return nil, err
+	}
+	if MaintenanceURLIDs(windows, time.Now())[url.ID] {
+		badge.Message, badge.Color = "maintenance", "blue"
+		return badge, nil
+	}
+
+	log, err := s.statusLogRepo.GetLastLogByURLID(ctx, s.db, url.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return badge, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if code, err := strconv.Atoi(log.Status); err == nil && IsDown(code) {
+		badge.Message, badge.Color = "down", "red"
+	} else {
+		badge.Message, badge.Color = "up", "brightgreen"
+	}
+	return badge, nil
+}
+
+func (s *badgeService) summaryBadge(ctx context.Context, url *models.URL, kind string) (*ShieldsResponse, error) {
+	summary, err := s.statusLogRepo.GetUptimeSummary(ctx, s.db, url.ID, time.Now().Add(-badgeWindows[kind]))
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == BadgeResponseTime {
+		if summary.TotalChecks == 0 {
+			return newShieldsResponse("response time", "n/a", "lightgrey"), nil
+		}
+		return newShieldsResponse("response time", fmt.Sprintf("%.0f ms", summary.AvgResponseTime), responseTimeColor(summary.AvgResponseTime)), nil
+	}
+
+	label := "uptime " + kind[len("uptime-"):]
+	if summary.TotalChecks == 0 {
+		return newShieldsResponse(label, "n/a", "lightgrey"), nil
+	}
+	return newShieldsResponse(label, strconv.FormatFloat(summary.UptimePercent, 'f', -1, 64)+"%", uptimeColor(summary.UptimePercent)), nil
+}
+
+func newShieldsResponse(label, message, color string) *ShieldsResponse {
+	return &ShieldsResponse{
+		SchemaVersion: 1,
+		Label:         label,
+		Message:       message,
+		Color:         color,
+		CacheSeconds:  int(badgeCacheTTL.Seconds()),
+	}
+}
+
+// invalidateBadges drops every cached badge of a URL so visibility and edits apply immediately.
+func invalidateBadges(ctx context.Context, redisClient *redis.Client, urlID uuid.UUID) {
+	keys := make([]string, 0, len(BadgeKinds))
+	for _, kind := range BadgeKinds {
+		keys = append(keys, utils.GetBadgeKey(urlID.String(), kind))
+	}
+	if err := redisClient.Del(ctx, keys...).Err(); err != nil {
+		utils.Warn(ctx, "Failed to invalidate badge cache", map[string]any{"url_id": urlID, "err": err.Error()})
+	}
+}
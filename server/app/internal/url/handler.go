@@ -17,6 +17,8 @@ type URLHandler interface {
 	GetHandler(c *gin.Context)
 	ListHandler(c *gin.Context)
 	GetUptimeStats(c *gin.Context)
+	ListLogsHandler(c *gin.Context)
+	GetSLAHandler(c *gin.Context)
 }
 
 type urlHandler struct {
@@ -67,7 +69,7 @@ func (h *urlHandler) UpdateHandler(c *gin.Context) {
 		return
 	}
 
-	urlResponse, errSvc := h.urlService.Update(c.Request.Context(), &urlRequest, publicID)
+	urlResponse, errSvc := h.urlService.Update(c.Request.Context(), &urlRequest, c.GetUint("user_id"), publicID)
 	if errSvc != nil {
 		utils.ErrorResponse(c, errSvc)
 		return
@@ -84,7 +86,7 @@ func (h *urlHandler) DeleteHandler(c *gin.Context) {
 		return
 	}
 
-	if err := h.urlService.Delete(c.Request.Context(), publicID); err != nil {
+	if err := h.urlService.Delete(c.Request.Context(), c.GetUint("user_id"), publicID); err != nil {
 		utils.ErrorResponse(c, err)
 		return
 	}
@@ -100,7 +102,7 @@ func (h *urlHandler) GetHandler(c *gin.Context) {
 		return
 	}
 
-	urlResponse, errSvc := h.urlService.FindByID(c.Request.Context(), publicID)
+	urlResponse, errSvc := h.urlService.FindByID(c.Request.Context(), c.GetUint("user_id"), publicID)
 	if errSvc != nil {
 		utils.ErrorResponse(c, errSvc)
 		return
@@ -147,11 +149,18 @@ func (h *urlHandler) ListHandler(c *gin.Context) {
 }
 
 func (h *urlHandler) GetUptimeStats(c *gin.Context) {
-	mode := c.DefaultQuery("mode", "day")
-	dateStr := c.DefaultQuery("date", "")
+	query := UptimeStatsQuery{
+		Mode:     c.DefaultQuery("mode", "day"),
+		Date:     c.DefaultQuery("date", ""),
+		From:     c.Query("from"),
+		To:       c.Query("to"),
+		Bucket:   c.Query("bucket"),
+		Timezone: c.Query("tz"),
+		Fields:   c.Query("fields"),
+	}
 	id := c.Param("id")
 
-	if mode != "day" && mode != "month" && mode != "year" {
+	if query.Mode != "day" && query.Mode != "month" && query.Mode != "year" {
 		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid mode", nil))
 		return
 	}
@@ -162,7 +171,7 @@ func (h *urlHandler) GetUptimeStats(c *gin.Context) {
 		return
 	}
 
-	stats, errSvc := h.urlService.GetUptimeStats(c.Request.Context(), idUUID, mode, dateStr)
+	stats, errSvc := h.urlService.GetUptimeStats(c.Request.Context(), c.GetUint("user_id"), idUUID, &query)
 	if errSvc != nil {
 		utils.ErrorResponse(c, errSvc)
 		return
@@ -170,3 +179,77 @@ func (h *urlHandler) GetUptimeStats(c *gin.Context) {
 
 	utils.SuccessResponse(c, stats)
 }
+
+func (h *urlHandler) ListLogsHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 500 {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid limit", err))
+		return
+	}
+
+	query := StatusLogQuery{
+		Cursor:      c.Query("cursor"),
+		Limit:       limit,
+		Order:       c.DefaultQuery("order", "desc"),
+		StatusClass: c.Query("status_class"),
+		State:       c.Query("state"),
+		ErrorKind:   c.Query("error_kind"),
+	}
+	if query.Order != "asc" && query.Order != "desc" {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid order", nil))
+		return
+	}
+	if query.StatusClass != "" && statusClassConditions[query.StatusClass] == "" {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid status_class", nil))
+		return
+	}
+	if query.State != "" && query.State != "up" && query.State != "down" {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid state", nil))
+		return
+	}
+	if query.ErrorKind != "" && errorKindConditions[query.ErrorKind] == "" {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid error_kind", nil))
+		return
+	}
+
+	for name, target := range map[string]**int64{"min_response_time": &query.MinResponseTime, "max_response_time": &query.MaxResponseTime} {
+		if v := c.Query(name); v != "" {
+			ms, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || ms < 0 {
+				utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid "+name, err))
+				return
+			}
+			*target = &ms
+		}
+	}
+
+	logs, nextCursor, errSvc := h.urlService.ListLogs(c.Request.Context(), c.GetUint("user_id"), id, &query)
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.CursorResponse(c, logs, limit, nextCursor)
+}
+
+func (h *urlHandler) GetSLAHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	sla, errSvc := h.urlService.GetSLA(c.Request.Context(), c.GetUint("user_id"), id)
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, sla)
+}
@@ -0,0 +1,132 @@
+package url
+
+import (
+	"context"
+	"time"
+	"uptimatic/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type IncidentRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, incident *models.Incident) error
+	FindOpenByURLID(ctx context.Context, tx *gorm.DB, urlID uint) (*models.Incident, error)
+	Resolve(ctx context.Context, tx *gorm.DB, incident *models.Incident, resolvedAt time.Time) error
+	ListOpenByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint) ([]models.Incident, error)
+	ListRecentByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint, limit int) ([]models.Incident, error)
+	StreamByURLID(ctx context.Context, tx *gorm.DB, urlID uint, start, end time.Time, fn func(*models.Incident) error) error
+	SummarizeByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint, start, end time.Time) ([]models.IncidentSummary, error)
+}
+
+type incidentRepository struct{}
+
+func NewIncidentRepository() IncidentRepository {
+	return &incidentRepository{}
+}
+
+func (r *incidentRepository) Create(ctx context.Context, tx *gorm.DB, incident *models.Incident) error {
+	return tx.WithContext(ctx).Create(incident).Error
+}
+
+func (r *incidentRepository) FindOpenByURLID(ctx context.Context, tx *gorm.DB, urlID uint) (*models.Incident, error) {
+	var incident models.Incident
+	err := tx.WithContext(ctx).Where("url_id = ? AND resolved_at IS NULL", urlID).Last(&incident).Error
+	if err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+func (r *incidentRepository) Resolve(ctx context.Context, tx *gorm.DB, incident *models.Incident, resolvedAt time.Time) error {
+	incident.ResolvedAt = &resolvedAt
+	return tx.WithContext(ctx).Model(incident).Update("resolved_at", resolvedAt).Error
+}
+
+func (r *incidentRepository) ListOpenByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint) ([]models.Incident, error) {
+	var incidents []models.Incident
+	if len(urlIDs) == 0 {
+		return incidents, nil
+	}
+
+	err := tx.WithContext(ctx).
+		Where("url_id IN ? AND resolved_at IS NULL", urlIDs).
+		Order("started_at DESC").
+		Find(&incidents).Error
+	if err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// ListRecentByURLIDs returns the latest incidents of the URLs, open or resolved, newest first.
+func (r *incidentRepository) ListRecentByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint, limit int) ([]models.Incident, error) {
+	var incidents []models.Incident
+	if len(urlIDs) == 0 {
+		return incidents, nil
+	}
+
+	err := tx.WithContext(ctx).
+		Where("url_id IN ?", urlIDs).
+		Order("started_at DESC, id DESC").
+		Limit(limit).
+		Find(&incidents).Error
+	if err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// StreamByURLID walks every incident overlapping [start, end) in start order.
+func (r *incidentRepository) StreamByURLID(ctx context.Context, tx *gorm.DB, urlID uint, start, end time.Time, fn func(*models.Incident) error) error {
+	rows, err := tx.WithContext(ctx).
+		Model(&models.Incident{}).
+		Where("url_id = ? AND started_at < ? AND (resolved_at IS NULL OR resolved_at >= ?)", urlID, end, start).
+		Order("started_at ASC, id ASC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var incident models.Incident
+		if err := tx.ScanRows(rows, &incident); err != nil {
+			return err
+		}
+		if err := fn(&incident); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// SummarizeByURLIDs counts the incidents overlapping [start, end) and sums their downtime, with each
+// incident clipped to the range and open incidents treated as lasting until end. URLs without
+// incidents in the range are left out.
+func (r *incidentRepository) SummarizeByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint, start, end time.Time) ([]models.IncidentSummary, error) {
+	var summaries []models.IncidentSummary
+	if len(urlIDs) == 0 {
+		return summaries, nil
+	}
+
+	err := tx.WithContext(ctx).Raw(`
+		SELECT
+			url_id,
+			COUNT(*) AS incident_count,
+			SUM(EXTRACT(EPOCH FROM outage_end - outage_start))::bigint AS downtime_seconds,
+			MAX(EXTRACT(EPOCH FROM outage_end - outage_start))::bigint AS longest_outage_seconds
+		FROM (
+			SELECT
+				url_id,
+				GREATEST(started_at, @start) AS outage_start,
+				LEAST(COALESCE(resolved_at, @end), @end) AS outage_end
+			FROM incidents
+			WHERE url_id IN @ids AND started_at < @end AND (resolved_at IS NULL OR resolved_at > @start)
+		) AS clipped
+		GROUP BY url_id;
+	`, map[string]any{"ids": urlIDs, "start": start, "end": end}).Scan(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
@@ -2,6 +2,7 @@ package url
 
 import (
 	"context"
+	"fmt"
 	"time"
 	"uptimatic/internal/models"
 
@@ -12,8 +13,27 @@ type StatusLogRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, log *models.StatusLog) error
 	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.StatusLog, error)
 	GetLastLogByURLID(ctx context.Context, tx *gorm.DB, urlID uint) (*models.StatusLog, error)
-	ListByURLID(ctx context.Context, tx *gorm.DB, urlID uint) ([]models.StatusLog, error)
-	GetUptimeStats(ctx context.Context, tx *gorm.DB, urlID uint, truncUnit string, start, end time.Time) ([]models.UptimeStat, error)
+	GetLastEffectiveLogByURLID(ctx context.Context, tx *gorm.DB, urlID uint) (*models.StatusLog, error)
+	ListByURLID(ctx context.Context, tx *gorm.DB, urlID uint, filter *StatusLogFilter) ([]models.StatusLog, error)
+	StreamByURLID(ctx context.Context, tx *gorm.DB, urlID uint, start, end time.Time, fn func(*models.StatusLog) error) error
+	GetUptimeStats(ctx context.Context, tx *gorm.DB, urlID uint, bucket, timezone string, start, end time.Time, fields map[string]bool, daily bool) ([]models.UptimeStat, error)
+	ListLastLogsByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint) ([]models.StatusLog, error)
+	GetDailyUptimeByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint, timezone string, start, end time.Time) ([]models.URLUptimeStat, error)
+	GetUptimeSummary(ctx context.Context, tx *gorm.DB, urlID uint, start time.Time) (*models.UptimeSummary, error)
+	GetUptimeSummaryByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint, start, end time.Time) ([]models.URLUptimeSummary, error)
+}
+
+// StatusLogFilter narrows a keyset-paginated listing of status logs; the cursor is the last row already seen.
+type StatusLogFilter struct {
+	CursorCheckedAt *time.Time
+	CursorID        uint
+	Ascending       bool
+	StatusClass     string
+	State           string
+	ErrorKind       string
+	MinResponseTime *int64
+	MaxResponseTime *int64
+	Limit           int
 }
 
 type statusLogRepository struct{}
@@ -44,37 +64,240 @@ func (r *statusLogRepository) GetLastLogByURLID(ctx context.Context, tx *gorm.DB
 	return &log, nil
 }
 
-func (r *statusLogRepository) ListByURLID(ctx context.Context, tx *gorm.DB, urlID uint) ([]models.StatusLog, error) {
-	var logs []models.StatusLog
-	err := tx.WithContext(ctx).Where("url_id = ?", urlID).Find(&logs).Error
+// GetLastEffectiveLogByURLID returns the latest log that counts toward the monitor state,
+// skipping checks recorded while alerts were suppressed.
+func (r *statusLogRepository) GetLastEffectiveLogByURLID(ctx context.Context, tx *gorm.DB, urlID uint) (*models.StatusLog, error) {
+	var log models.StatusLog
+	err := tx.WithContext(ctx).Where("url_id = ? AND NOT in_maintenance AND NOT dependent", urlID).Last(&log).Error
 	if err != nil {
 		return nil, err
 	}
+	return &log, nil
+}
+
+func (r *statusLogRepository) ListByURLID(ctx context.Context, tx *gorm.DB, urlID uint, filter *StatusLogFilter) ([]models.StatusLog, error) {
+	var logs []models.StatusLog
+
+	query := tx.WithContext(ctx).Where("url_id = ?", urlID)
+	if filter.CursorCheckedAt != nil {
+		if filter.Ascending {
+			query = query.Where("(checked_at, id) > (?, ?)", *filter.CursorCheckedAt, filter.CursorID)
+		} else {
+			query = query.Where("(checked_at, id) < (?, ?)", *filter.CursorCheckedAt, filter.CursorID)
+		}
+	}
+	if condition, ok := statusClassConditions[filter.StatusClass]; ok {
+		query = query.Where(condition)
+	}
+	// Down matches IsDown, which incidents and alerts are raised on.
+	switch filter.State {
+	case "up":
+		query = query.Where("status > 0 AND status < 400")
+	case "down":
+		query = query.Where("(status = 0 OR status >= 400)")
+	}
+	if condition, ok := errorKindConditions[filter.ErrorKind]; ok {
+		query = query.Where(condition)
+	}
+	if filter.MinResponseTime != nil {
+		query = query.Where("response_time >= ?", *filter.MinResponseTime)
+	}
+	if filter.MaxResponseTime != nil {
+		query = query.Where("response_time <= ?", *filter.MaxResponseTime)
+	}
+
+	if filter.Ascending {
+		query = query.Order("checked_at ASC, id ASC")
+	} else {
+		query = query.Order("checked_at DESC, id DESC")
+	}
+
+	if err := query.Limit(filter.Limit).Find(&logs).Error; err != nil {
+		return nil, err
+	}
 	return logs, nil
 }
 
-func (r *statusLogRepository) GetUptimeStats(ctx context.Context, tx *gorm.DB, urlID uint, truncUnit string, start, end time.Time) ([]models.UptimeStat, error) {
+// StreamByURLID walks every log in [start, end) oldest first without loading the range into memory.
+func (r *statusLogRepository) StreamByURLID(ctx context.Context, tx *gorm.DB, urlID uint, start, end time.Time, fn func(*models.StatusLog) error) error {
+	rows, err := tx.WithContext(ctx).
+		Model(&models.StatusLog{}).
+		Where("url_id = ? AND checked_at >= ? AND checked_at < ?", urlID, start, end).
+		Order("checked_at ASC, id ASC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var log models.StatusLog
+		if err := tx.ScanRows(rows, &log); err != nil {
+			return err
+		}
+		if err := fn(&log); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetUptimeStats aggregates checks into buckets. Buckets of an hour or more are read from the rollups
+// (the daily rollup when daily is set), finer buckets from the raw logs.
+func (r *statusLogRepository) GetUptimeStats(ctx context.Context, tx *gorm.DB, urlID uint, bucket, timezone string, start, end time.Time, fields map[string]bool, daily bool) ([]models.UptimeStat, error) {
+	args := map[string]any{"id": urlID, "tz": timezone, "start": start, "end": end}
+	if rollupBucket(bucket) {
+		return r.getRollupUptimeStats(ctx, tx, bucket, args, fields, daily)
+	}
+
 	var results []models.UptimeStat
 
-	query := `
+	query := fmt.Sprintf(`
 		SELECT
-			date_trunc(?, checked_at) AS bucket_start,
+			%s AS bucket_start,
 			COUNT(*) AS total_checks,
-			COUNT(*) FILTER (WHERE status BETWEEN 200 AND 299) AS up_checks,
-			ROUND(
-				COUNT(*) FILTER (WHERE status BETWEEN 200 AND 299) * 100.0 / COUNT(*),
+			COUNT(*) FILTER (WHERE status BETWEEN 200 AND 299 AND NOT in_maintenance) AS up_checks,
+			COUNT(*) FILTER (WHERE in_maintenance) AS maintenance_checks,
+			COALESCE(ROUND(
+				COUNT(*) FILTER (WHERE status BETWEEN 200 AND 299 AND NOT in_maintenance) * 100.0 /
+				NULLIF(COUNT(*) FILTER (WHERE NOT in_maintenance), 0),
 				2
-			) AS uptime_percent
+			), 100) AS uptime_percent%s
 		FROM status_logs
-		WHERE url_id = ?
-		AND checked_at BETWEEN ? AND ?
+		WHERE url_id = @id
+		AND checked_at >= @start AND checked_at < @end
 		GROUP BY bucket_start
 		ORDER BY bucket_start ASC;
+	`, bucketSQL(bucket, "checked_at"), statFieldsSQL(fields))
+
+	if err := tx.WithContext(ctx).Raw(query, args).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *statusLogRepository) getRollupUptimeStats(ctx context.Context, tx *gorm.DB, bucket string, args map[string]any, fields map[string]bool, daily bool) ([]models.UptimeStat, error) {
+	var rows []rollupStat
+
+	query := fmt.Sprintf(`
+		SELECT
+			%s AS bucket_start,
+			SUM(total_checks) AS total_checks,
+			SUM(up_checks) AS up_checks,
+			SUM(maintenance_checks) AS maintenance_checks,
+			%s AS uptime_percent%s
+		FROM (%s
+		) AS src
+		GROUP BY 1
+		ORDER BY 1 ASC;
+	`, bucketSQL(bucket, "bucket_start"), rollupUptimeSQL, rollupStatFieldsSQL(fields), rollupSourceSQL("url_id = @id", daily))
+
+	if err := tx.WithContext(ctx).Raw(query, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	results := make([]models.UptimeStat, 0, len(rows))
+	for _, row := range rows {
+		row.fillPercentiles(fields)
+		results = append(results, row.UptimeStat)
+	}
+	return results, nil
+}
+
+func (r *statusLogRepository) ListLastLogsByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint) ([]models.StatusLog, error) {
+	var logs []models.StatusLog
+	if len(urlIDs) == 0 {
+		return logs, nil
+	}
+
+	query := `
+		SELECT DISTINCT ON (url_id) *
+		FROM status_logs
+		WHERE url_id IN ?
+		ORDER BY url_id, checked_at DESC, id DESC;
 	`
 
-	if err := tx.WithContext(ctx).Raw(query, truncUnit, urlID, start, end).Scan(&results).Error; err != nil {
+	if err := tx.WithContext(ctx).Raw(query, urlIDs).Scan(&logs).Error; err != nil {
 		return nil, err
 	}
+	return logs, nil
+}
+
+func (r *statusLogRepository) GetDailyUptimeByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint, timezone string, start, end time.Time) ([]models.URLUptimeStat, error) {
+	var results []models.URLUptimeStat
+	if len(urlIDs) == 0 {
+		return results, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			url_id,
+			date_trunc('day', bucket_start, @tz) AS bucket_start,
+			SUM(total_checks) AS total_checks,
+			SUM(up_checks) AS up_checks,
+			SUM(maintenance_checks) AS maintenance_checks,
+			%s AS uptime_percent,
+			%s
+		FROM (%s
+		) AS src
+		GROUP BY url_id, 2
+		ORDER BY url_id, 2 ASC;
+	`, rollupUptimeSQL, rollupStatFields["avg"], rollupSourceSQL("url_id IN @ids", false))
 
+	args := map[string]any{"tz": timezone, "ids": urlIDs, "start": start, "end": end}
+	if err := tx.WithContext(ctx).Raw(query, args).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetUptimeSummary aggregates every check since start into a single uptime and average response time.
+func (r *statusLogRepository) GetUptimeSummary(ctx context.Context, tx *gorm.DB, urlID uint, start time.Time) (*models.UptimeSummary, error) {
+	var summary models.UptimeSummary
+
+	query := fmt.Sprintf(`
+		SELECT
+			COALESCE(SUM(total_checks), 0) AS total_checks,
+			COALESCE(SUM(up_checks), 0) AS up_checks,
+			COALESCE(SUM(maintenance_checks), 0) AS maintenance_checks,
+			%s AS uptime_percent,
+			COALESCE(SUM(response_time_sum)::float8 / NULLIF(SUM(response_time_count), 0), 0) AS avg_response_time
+		FROM (%s
+		) AS src;
+	`, rollupUptimeSQL, rollupSourceSQL("url_id = @id", false))
+
+	args := map[string]any{"id": urlID, "start": start, "end": time.Now()}
+	if err := tx.WithContext(ctx).Raw(query, args).Scan(&summary).Error; err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// GetUptimeSummaryByURLIDs is GetUptimeSummary over [start, end) for several URLs at once.
+// URLs without checks in the range are left out.
+func (r *statusLogRepository) GetUptimeSummaryByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint, start, end time.Time) ([]models.URLUptimeSummary, error) {
+	var results []models.URLUptimeSummary
+	if len(urlIDs) == 0 {
+		return results, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			url_id,
+			SUM(total_checks) AS total_checks,
+			SUM(up_checks) AS up_checks,
+			SUM(maintenance_checks) AS maintenance_checks,
+			%s AS uptime_percent,
+			COALESCE(SUM(response_time_sum)::float8 / NULLIF(SUM(response_time_count), 0), 0) AS avg_response_time
+		FROM (%s
+		) AS src
+		GROUP BY url_id;
+	`, rollupUptimeSQL, rollupSourceSQL("url_id IN @ids", false))
+
+	args := map[string]any{"ids": urlIDs, "start": start, "end": end}
+	if err := tx.WithContext(ctx).Raw(query, args).Scan(&results).Error; err != nil {
+		return nil, err
+	}
 	return results, nil
 }
@@ -0,0 +1,336 @@
+package url
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"uptimatic/internal/models"
+
+	"github.com/robfig/cron/v3"
+)
+
+// rrule is the subset of RFC 5545 recurrence rules supported by maintenance windows.
+type rrule struct {
+	freq       string
+	interval   int
+	byDay      map[time.Weekday]bool
+	byMonthDay map[int]bool
+	byHour     []int
+	byMinute   []int
+	until      *time.Time
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func parseRRule(spec string) (*rrule, error) {
+	spec = strings.TrimPrefix(strings.TrimSpace(spec), "RRULE:")
+	r := &rrule{interval: 1}
+
+	for _, part := range strings.Split(spec, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rrule part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			switch strings.ToUpper(value) {
+			case "DAILY", "WEEKLY", "MONTHLY":
+				r.freq = strings.ToUpper(value)
+			default:
+				return nil, fmt.Errorf("unsupported rrule frequency %q", value)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid rrule interval %q", value)
+			}
+			r.interval = interval
+		case "BYDAY":
+			r.byDay = map[time.Weekday]bool{}
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("invalid rrule weekday %q", day)
+				}
+				r.byDay[weekday] = true
+			}
+		case "BYMONTHDAY":
+			days, err := parseRRuleInts(value, 1, 31)
+			if err != nil {
+				return nil, err
+			}
+			r.byMonthDay = map[int]bool{}
+			for _, day := range days {
+				r.byMonthDay[day] = true
+			}
+		case "BYHOUR":
+			hours, err := parseRRuleInts(value, 0, 23)
+			if err != nil {
+				return nil, err
+			}
+			r.byHour = hours
+		case "BYMINUTE":
+			minutes, err := parseRRuleInts(value, 0, 59)
+			if err != nil {
+				return nil, err
+			}
+			r.byMinute = minutes
+		case "UNTIL":
+			until, err := time.Parse("20060102T150405Z", value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rrule until %q", value)
+			}
+			r.until = &until
+		default:
+			return nil, fmt.Errorf("unsupported rrule part %q", key)
+		}
+	}
+
+	if r.freq == "" {
+		return nil, fmt.Errorf("rrule is missing FREQ")
+	}
+	return r, nil
+}
+
+func parseRRuleInts(value string, min, max int) ([]int, error) {
+	var result []int
+	for _, v := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < min || n > max {
+			return nil, fmt.Errorf("invalid rrule value %q", v)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+// civilDays counts calendar days between two dates, ignoring DST shifts.
+func civilDays(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+func (r *rrule) occursOn(day, dtstart time.Time) bool {
+	days := civilDays(dtstart, day)
+	if days < 0 {
+		return false
+	}
+
+	switch r.freq {
+	case "DAILY":
+		if days%r.interval != 0 {
+			return false
+		}
+	case "WEEKLY":
+		weekStart := dtstart.AddDate(0, 0, -((int(dtstart.Weekday()) + 6) % 7))
+		if (civilDays(weekStart, day)/7)%r.interval != 0 {
+			return false
+		}
+		if r.byDay == nil && day.Weekday() != dtstart.Weekday() {
+			return false
+		}
+	case "MONTHLY":
+		months := (day.Year()-dtstart.Year())*12 + int(day.Month()-dtstart.Month())
+		if months%r.interval != 0 {
+			return false
+		}
+		if r.byDay == nil && r.byMonthDay == nil && day.Day() != dtstart.Day() {
+			return false
+		}
+	}
+
+	if r.byDay != nil && !r.byDay[day.Weekday()] {
+		return false
+	}
+	if r.byMonthDay != nil && !r.byMonthDay[day.Day()] {
+		return false
+	}
+	return true
+}
+
+func (r *rrule) startsOn(day, dtstart time.Time) []time.Time {
+	hours := r.byHour
+	if hours == nil {
+		hours = []int{dtstart.Hour()}
+	}
+	minutes := r.byMinute
+	if minutes == nil {
+		minutes = []int{dtstart.Minute()}
+	}
+
+	var starts []time.Time
+	for _, h := range hours {
+		for _, m := range minutes {
+			starts = append(starts, time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location()))
+		}
+	}
+	return starts
+}
+
+// between returns the occurrence starts in (after, before]. Only the days of that range are
+// visited, so the cost depends on the window duration and not on how old the series is.
+func (r *rrule) between(dtstart, after, before time.Time) []time.Time {
+	if after.Before(dtstart) {
+		after = dtstart.Add(-time.Second)
+	}
+	if r.until != nil && before.After(*r.until) {
+		before = *r.until
+	}
+	if before.Before(after) {
+		return nil
+	}
+
+	loc := dtstart.Location()
+	end := before.In(loc)
+	var starts []time.Time
+	for day := after.In(loc); civilDays(day, end) >= 0; day = day.AddDate(0, 0, 1) {
+		if !r.occursOn(day, dtstart) {
+			continue
+		}
+		for _, start := range r.startsOn(day, dtstart) {
+			if start.After(after) && !start.After(before) {
+				starts = append(starts, start)
+			}
+		}
+	}
+	return starts
+}
+
+// ValidateRecurrence checks that a recurrence spec can be evaluated for the given recurrence type.
+func ValidateRecurrence(recurrenceType, recurrence string) error {
+	switch recurrenceType {
+	case models.RecurrenceOnce:
+		return nil
+	case models.RecurrenceCron:
+		_, err := cron.ParseStandard(recurrence)
+		return err
+	case models.RecurrenceRRule:
+		_, err := parseRRule(recurrence)
+		return err
+	default:
+		return fmt.Errorf("unknown recurrence type %q", recurrenceType)
+	}
+}
+
+// MaintenanceActiveAt reports whether t falls inside one of the window's occurrences.
+func MaintenanceActiveAt(w *models.MaintenanceWindow, t time.Time) bool {
+	if !w.Active || t.Before(w.StartsAt) {
+		return false
+	}
+	if w.EndsAt != nil && !t.Before(*w.EndsAt) {
+		return false
+	}
+	if w.RecurrenceType == models.RecurrenceOnce {
+		return w.EndsAt != nil
+	}
+
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	duration := time.Duration(w.DurationMinutes) * time.Minute
+	from := t.Add(-duration)
+
+	switch w.RecurrenceType {
+	case models.RecurrenceCron:
+		schedule, err := cron.ParseStandard(w.Recurrence)
+		if err != nil {
+			return false
+		}
+		if from.Before(w.StartsAt) {
+			from = w.StartsAt.Add(-time.Second)
+		}
+		next := schedule.Next(from.In(loc))
+		return !next.After(t)
+
+	case models.RecurrenceRRule:
+		rule, err := parseRRule(w.Recurrence)
+		if err != nil {
+			return false
+		}
+		return len(rule.between(w.StartsAt.In(loc), from, t)) > 0
+	}
+
+	return false
+}
+
+// MaintenanceOccurrence returns the occurrence of the window running at t or, when none is, the
+// first one starting in (t, t+horizon]. Occurrences are cut short at the window's end.
+func MaintenanceOccurrence(w *models.MaintenanceWindow, t time.Time, horizon time.Duration) (time.Time, time.Time, bool) {
+	if !w.Active {
+		return time.Time{}, time.Time{}, false
+	}
+
+	var start, end time.Time
+	if w.RecurrenceType == models.RecurrenceOnce {
+		if w.EndsAt == nil {
+			return time.Time{}, time.Time{}, false
+		}
+		start, end = w.StartsAt, *w.EndsAt
+	} else {
+		loc, err := time.LoadLocation(w.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		duration := time.Duration(w.DurationMinutes) * time.Minute
+		from, to := t.Add(-duration), t.Add(horizon)
+		if from.Before(w.StartsAt) {
+			from = w.StartsAt.Add(-time.Second)
+		}
+
+		switch w.RecurrenceType {
+		case models.RecurrenceCron:
+			schedule, err := cron.ParseStandard(w.Recurrence)
+			if err != nil {
+				return time.Time{}, time.Time{}, false
+			}
+			start = schedule.Next(from.In(loc))
+		case models.RecurrenceRRule:
+			rule, err := parseRRule(w.Recurrence)
+			if err != nil {
+				return time.Time{}, time.Time{}, false
+			}
+			starts := rule.between(w.StartsAt.In(loc), from, to)
+			if len(starts) == 0 {
+				return time.Time{}, time.Time{}, false
+			}
+			start = starts[0]
+		default:
+			return time.Time{}, time.Time{}, false
+		}
+		end = start.Add(duration)
+		if w.EndsAt != nil && end.After(*w.EndsAt) {
+			end = *w.EndsAt
+		}
+	}
+
+	if start.IsZero() || start.After(t.Add(horizon)) || !end.After(t) || !end.After(start) {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
+
+// MaintenanceURLIDs returns the IDs of the URLs covered by a window that is active at t.
+func MaintenanceURLIDs(windows []models.MaintenanceWindow, t time.Time) map[uint]bool {
+	ids := map[uint]bool{}
+	for i := range windows {
+		if !MaintenanceActiveAt(&windows[i], t) {
+			continue
+		}
+		for _, u := range windows[i].URLs {
+			ids[u.ID] = true
+		}
+	}
+	return ids
+}
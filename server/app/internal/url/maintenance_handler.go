@@ -0,0 +1,114 @@
+package url
+
+import (
+	"net/http"
+	"uptimatic/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type MaintenanceHandler interface {
+	CreateHandler(c *gin.Context)
+	UpdateHandler(c *gin.Context)
+	DeleteHandler(c *gin.Context)
+	GetHandler(c *gin.Context)
+	ListHandler(c *gin.Context)
+}
+
+type maintenanceHandler struct {
+	maintenanceService MaintenanceService
+	validate           *validator.Validate
+}
+
+func NewMaintenanceHandler(maintenanceService MaintenanceService, validate *validator.Validate) MaintenanceHandler {
+	return &maintenanceHandler{maintenanceService, validate}
+}
+
+func (h *maintenanceHandler) CreateHandler(c *gin.Context) {
+	var req MaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid JSON payload", err))
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		utils.BindErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	resp, errSvc := h.maintenanceService.Create(c.Request.Context(), &req, c.GetUint("user_id"))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *maintenanceHandler) UpdateHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	var req MaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid JSON payload", err))
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		utils.BindErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	resp, errSvc := h.maintenanceService.Update(c.Request.Context(), &req, c.GetUint("user_id"), id)
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *maintenanceHandler) DeleteHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	if errSvc := h.maintenanceService.Delete(c.Request.Context(), c.GetUint("user_id"), id); errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, nil)
+}
+
+func (h *maintenanceHandler) GetHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	resp, errSvc := h.maintenanceService.FindByID(c.Request.Context(), c.GetUint("user_id"), id)
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
+
+func (h *maintenanceHandler) ListHandler(c *gin.Context) {
+	resp, errSvc := h.maintenanceService.ListByUserID(c.Request.Context(), c.GetUint("user_id"))
+	if errSvc != nil {
+		utils.ErrorResponse(c, errSvc)
+		return
+	}
+
+	utils.SuccessResponse(c, resp)
+}
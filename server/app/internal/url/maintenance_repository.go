@@ -0,0 +1,74 @@
+package url
+
+import (
+	"context"
+	"uptimatic/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MaintenanceRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, window *models.MaintenanceWindow) error
+	Update(ctx context.Context, tx *gorm.DB, window *models.MaintenanceWindow) error
+	Delete(ctx context.Context, tx *gorm.DB, window *models.MaintenanceWindow) error
+	FindByPublicID(ctx context.Context, tx *gorm.DB, userID uint, publicID uuid.UUID) (*models.MaintenanceWindow, error)
+	ListByUserID(ctx context.Context, tx *gorm.DB, userID uint) ([]models.MaintenanceWindow, error)
+	ListActiveByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint) ([]models.MaintenanceWindow, error)
+}
+
+type maintenanceRepository struct{}
+
+func NewMaintenanceRepository() MaintenanceRepository {
+	return &maintenanceRepository{}
+}
+
+func (r *maintenanceRepository) Create(ctx context.Context, tx *gorm.DB, window *models.MaintenanceWindow) error {
+	return tx.WithContext(ctx).Create(window).Error
+}
+
+func (r *maintenanceRepository) Update(ctx context.Context, tx *gorm.DB, window *models.MaintenanceWindow) error {
+	if err := tx.WithContext(ctx).Omit("URLs").Save(window).Error; err != nil {
+		return err
+	}
+	return tx.WithContext(ctx).Model(window).Association("URLs").Replace(window.URLs)
+}
+
+func (r *maintenanceRepository) Delete(ctx context.Context, tx *gorm.DB, window *models.MaintenanceWindow) error {
+	return tx.WithContext(ctx).Select("URLs").Delete(window).Error
+}
+
+func (r *maintenanceRepository) FindByPublicID(ctx context.Context, tx *gorm.DB, userID uint, publicID uuid.UUID) (*models.MaintenanceWindow, error) {
+	var window models.MaintenanceWindow
+	err := tx.WithContext(ctx).Preload("URLs").First(&window, "public_id = ? AND user_id = ?", publicID, userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &window, nil
+}
+
+func (r *maintenanceRepository) ListByUserID(ctx context.Context, tx *gorm.DB, userID uint) ([]models.MaintenanceWindow, error) {
+	var windows []models.MaintenanceWindow
+	err := tx.WithContext(ctx).Preload("URLs").Where("user_id = ?", userID).Order("starts_at DESC").Find(&windows).Error
+	if err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+func (r *maintenanceRepository) ListActiveByURLIDs(ctx context.Context, tx *gorm.DB, urlIDs []uint) ([]models.MaintenanceWindow, error) {
+	var windows []models.MaintenanceWindow
+	if len(urlIDs) == 0 {
+		return windows, nil
+	}
+
+	subQuery := tx.WithContext(ctx).Table("maintenance_window_urls").Select("maintenance_window_id").Where("url_id IN ?", urlIDs)
+	err := tx.WithContext(ctx).
+		Preload("URLs", "id IN ?", urlIDs).
+		Where("active = ? AND id IN (?)", true, subQuery).
+		Find(&windows).Error
+	if err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
@@ -0,0 +1,207 @@
+package url
+
+import (
+	"context"
+	"net/http"
+	"time"
+	"uptimatic/internal/db"
+	"uptimatic/internal/models"
+	"uptimatic/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxMaintenanceDurationMinutes bounds recurring windows, which also bounds how far back
+// MaintenanceActiveAt has to look for an occurrence that is still running.
+const maxMaintenanceDurationMinutes = 7 * 24 * 60
+
+type MaintenanceService interface {
+	Create(ctx context.Context, req *MaintenanceRequest, userID uint) (*MaintenanceResponse, *utils.AppError)
+	Update(ctx context.Context, req *MaintenanceRequest, userID uint, id uuid.UUID) (*MaintenanceResponse, *utils.AppError)
+	Delete(ctx context.Context, userID uint, id uuid.UUID) *utils.AppError
+	FindByID(ctx context.Context, userID uint, id uuid.UUID) (*MaintenanceResponse, *utils.AppError)
+	ListByUserID(ctx context.Context, userID uint) ([]MaintenanceResponse, *utils.AppError)
+}
+
+type maintenanceService struct {
+	db              *gorm.DB
+	urlRepo         UrlRepository
+	maintenanceRepo MaintenanceRepository
+}
+
+func NewMaintenanceService(db *gorm.DB, urlRepo UrlRepository, maintenanceRepo MaintenanceRepository) MaintenanceService {
+	return &maintenanceService{db, urlRepo, maintenanceRepo}
+}
+
+func (s *maintenanceService) Create(ctx context.Context, req *MaintenanceRequest, userID uint) (*MaintenanceResponse, *utils.AppError) {
+	utils.Info(ctx, "Creating maintenance window", map[string]any{"user_id": userID, "title": req.Title})
+
+	window := &models.MaintenanceWindow{
+		UserID:   userID,
+		PublicID: uuid.New(),
+	}
+	if appErr := s.apply(ctx, window, req, userID); appErr != nil {
+		return nil, appErr
+	}
+
+	if err := s.maintenanceRepo.Create(ctx, s.db, window); err != nil {
+		utils.Error(ctx, "Failed to create maintenance window", map[string]any{"user_id": userID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error creating maintenance window", err)
+	}
+
+	utils.Info(ctx, "Maintenance window created successfully", map[string]any{"maintenance_id": window.PublicID, "user_id": userID})
+	return newMaintenanceResponse(window), nil
+}
+
+func (s *maintenanceService) Update(ctx context.Context, req *MaintenanceRequest, userID uint, id uuid.UUID) (*MaintenanceResponse, *utils.AppError) {
+	utils.Info(ctx, "Updating maintenance window", map[string]any{"maintenance_id": id})
+
+	window, err := s.maintenanceRepo.FindByPublicID(ctx, s.db, userID, id)
+	if err != nil {
+		utils.Warn(ctx, "Maintenance window not found for update", map[string]any{"maintenance_id": id})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Maintenance window not found", err)
+	}
+
+	if appErr := s.apply(ctx, window, req, userID); appErr != nil {
+		return nil, appErr
+	}
+
+	err = db.WithTransaction(s.db, func(tx *gorm.DB) error {
+		return s.maintenanceRepo.Update(ctx, tx, window)
+	})
+	if err != nil {
+		utils.Error(ctx, "Failed to update maintenance window", map[string]any{"maintenance_id": id, "err": err.Error()})
+		return nil, utils.InternalServerError("Error updating maintenance window", err)
+	}
+
+	utils.Info(ctx, "Maintenance window updated successfully", map[string]any{"maintenance_id": id})
+	return newMaintenanceResponse(window), nil
+}
+
+func (s *maintenanceService) Delete(ctx context.Context, userID uint, id uuid.UUID) *utils.AppError {
+	utils.Info(ctx, "Deleting maintenance window", map[string]any{"maintenance_id": id})
+
+	window, err := s.maintenanceRepo.FindByPublicID(ctx, s.db, userID, id)
+	if err != nil {
+		utils.Warn(ctx, "Maintenance window not found for deletion", map[string]any{"maintenance_id": id})
+		return utils.NewAppError(http.StatusNotFound, utils.NotFound, "Maintenance window not found", err)
+	}
+
+	if err := s.maintenanceRepo.Delete(ctx, s.db, window); err != nil {
+		utils.Error(ctx, "Failed to delete maintenance window", map[string]any{"maintenance_id": id, "err": err.Error()})
+		return utils.InternalServerError("Error deleting maintenance window", err)
+	}
+
+	utils.Info(ctx, "Maintenance window deleted successfully", map[string]any{"maintenance_id": id})
+	return nil
+}
+
+func (s *maintenanceService) FindByID(ctx context.Context, userID uint, id uuid.UUID) (*MaintenanceResponse, *utils.AppError) {
+	window, err := s.maintenanceRepo.FindByPublicID(ctx, s.db, userID, id)
+	if err != nil {
+		utils.Warn(ctx, "Maintenance window not found", map[string]any{"maintenance_id": id})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Maintenance window not found", err)
+	}
+	return newMaintenanceResponse(window), nil
+}
+
+func (s *maintenanceService) ListByUserID(ctx context.Context, userID uint) ([]MaintenanceResponse, *utils.AppError) {
+	windows, err := s.maintenanceRepo.ListByUserID(ctx, s.db, userID)
+	if err != nil {
+		utils.Error(ctx, "Failed to list maintenance windows", map[string]any{"user_id": userID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error listing maintenance windows", err)
+	}
+
+	responses := []MaintenanceResponse{}
+	for i := range windows {
+		responses = append(responses, *newMaintenanceResponse(&windows[i]))
+	}
+	return responses, nil
+}
+
+// apply validates the request and copies it onto the window, resolving monitor IDs for the user.
+func (s *maintenanceService) apply(ctx context.Context, window *models.MaintenanceWindow, req *MaintenanceRequest, userID uint) *utils.AppError {
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = utils.DefaultTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid timezone", err)
+	}
+
+	switch req.RecurrenceType {
+	case models.RecurrenceOnce:
+		if req.EndsAt == nil || !req.EndsAt.After(req.StartsAt) {
+			return utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "One-off maintenance requires ends_at after starts_at", nil)
+		}
+	default:
+		if req.DurationMinutes < 1 {
+			return utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Recurring maintenance requires duration_minutes", nil)
+		}
+		if req.DurationMinutes > maxMaintenanceDurationMinutes {
+			return utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Recurring maintenance cannot last longer than 7 days", nil)
+		}
+		if err := ValidateRecurrence(req.RecurrenceType, req.Recurrence); err != nil {
+			return utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid recurrence: "+err.Error(), err)
+		}
+	}
+
+	// A repeated monitor would make the count below fall short and read as a missing one.
+	urlIDs := make([]uuid.UUID, 0, len(req.URLIDs))
+	seen := map[uuid.UUID]bool{}
+	for _, id := range req.URLIDs {
+		if !seen[id] {
+			seen[id] = true
+			urlIDs = append(urlIDs, id)
+		}
+	}
+
+	urls, err := s.urlRepo.ListByPublicIDs(ctx, s.db, userID, urlIDs)
+	if err != nil {
+		utils.Error(ctx, "Failed to resolve maintenance URLs", map[string]any{"user_id": userID, "err": err.Error()})
+		return utils.InternalServerError("Error finding urls", err)
+	}
+	if len(urls) != len(urlIDs) {
+		return utils.NewAppError(http.StatusNotFound, utils.NotFound, "Url not found", nil)
+	}
+
+	window.Title = req.Title
+	window.Description = req.Description
+	window.StartsAt = req.StartsAt.UTC()
+	window.EndsAt = nil
+	if req.EndsAt != nil {
+		endsAt := req.EndsAt.UTC()
+		window.EndsAt = &endsAt
+	}
+	window.RecurrenceType = req.RecurrenceType
+	window.Recurrence = req.Recurrence
+	window.DurationMinutes = req.DurationMinutes
+	window.Timezone = timezone
+	window.Active = *req.Active
+	window.URLs = urls
+	return nil
+}
+
+func newMaintenanceResponse(window *models.MaintenanceWindow) *MaintenanceResponse {
+	urlIDs := []uuid.UUID{}
+	for _, u := range window.URLs {
+		urlIDs = append(urlIDs, u.PublicID)
+	}
+
+	return &MaintenanceResponse{
+		ID:              window.PublicID,
+		Title:           window.Title,
+		Description:     window.Description,
+		URLIDs:          urlIDs,
+		StartsAt:        window.StartsAt,
+		EndsAt:          window.EndsAt,
+		RecurrenceType:  window.RecurrenceType,
+		Recurrence:      window.Recurrence,
+		DurationMinutes: window.DurationMinutes,
+		Timezone:        window.Timezone,
+		Active:          window.Active,
+		InProgress:      MaintenanceActiveAt(window, time.Now()),
+		CreatedAt:       window.CreatedAt,
+	}
+}
@@ -0,0 +1,266 @@
+package url
+
+import (
+	"testing"
+	"time"
+	"uptimatic/internal/models"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+		check   func(*rrule) bool
+	}{
+		{name: "daily", spec: "FREQ=DAILY", check: func(r *rrule) bool { return r.freq == "DAILY" && r.interval == 1 }},
+		{name: "prefix and case", spec: " RRULE:freq=weekly;byday=mo,we ", check: func(r *rrule) bool {
+			return r.freq == "WEEKLY" && r.byDay[time.Monday] && r.byDay[time.Wednesday] && len(r.byDay) == 2
+		}},
+		{name: "interval and times", spec: "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=1,15;BYHOUR=3;BYMINUTE=30", check: func(r *rrule) bool {
+			return r.interval == 2 && r.byMonthDay[1] && r.byMonthDay[15] && len(r.byHour) == 1 && r.byHour[0] == 3 && r.byMinute[0] == 30
+		}},
+		{name: "until", spec: "FREQ=DAILY;UNTIL=20260301T000000Z", check: func(r *rrule) bool {
+			return r.until != nil && r.until.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+		}},
+		{name: "missing freq", spec: "INTERVAL=2", wantErr: true},
+		{name: "unsupported freq", spec: "FREQ=YEARLY", wantErr: true},
+		{name: "zero interval", spec: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "bad weekday", spec: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{name: "month day out of range", spec: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{name: "hour out of range", spec: "FREQ=DAILY;BYHOUR=24", wantErr: true},
+		{name: "bad until", spec: "FREQ=DAILY;UNTIL=2026-03-01", wantErr: true},
+		{name: "unknown part", spec: "FREQ=DAILY;COUNT=3", wantErr: true},
+		{name: "malformed part", spec: "FREQ=DAILY;BYHOUR", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseRRule(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseRRule(%q) succeeded, want error", tt.spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRRule(%q) failed: %v", tt.spec, err)
+			}
+			if !tt.check(r) {
+				t.Errorf("parseRRule(%q) = %+v", tt.spec, r)
+			}
+		})
+	}
+}
+
+func TestRRuleBetween(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+	utc := func(y int, m time.Month, d, h, min int) time.Time { return time.Date(y, m, d, h, min, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		spec    string
+		dtstart time.Time
+		after   time.Time
+		before  time.Time
+		want    []time.Time
+	}{
+		{
+			// 09:00 local is 14:00 UTC before the 2026-03-08 spring forward and 13:00 UTC after it.
+			name:    "daily across spring forward",
+			spec:    "FREQ=DAILY",
+			dtstart: time.Date(2026, 3, 1, 9, 0, 0, 0, ny),
+			after:   utc(2026, 3, 6, 0, 0),
+			before:  utc(2026, 3, 10, 0, 0),
+			want:    []time.Time{utc(2026, 3, 6, 14, 0), utc(2026, 3, 7, 14, 0), utc(2026, 3, 8, 13, 0), utc(2026, 3, 9, 13, 0)},
+		},
+		{
+			name:    "daily across fall back",
+			spec:    "FREQ=DAILY;BYHOUR=9;BYMINUTE=0",
+			dtstart: time.Date(2026, 10, 1, 9, 0, 0, 0, ny),
+			after:   utc(2026, 10, 31, 0, 0),
+			before:  utc(2026, 11, 3, 0, 0),
+			want:    []time.Time{utc(2026, 10, 31, 13, 0), utc(2026, 11, 1, 14, 0), utc(2026, 11, 2, 14, 0)},
+		},
+		{
+			name:    "weekly by day with interval",
+			spec:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR",
+			dtstart: utc(2026, 1, 5, 2, 0),
+			after:   utc(2026, 1, 5, 0, 0),
+			before:  utc(2026, 1, 31, 0, 0),
+			want:    []time.Time{utc(2026, 1, 5, 2, 0), utc(2026, 1, 9, 2, 0), utc(2026, 1, 19, 2, 0), utc(2026, 1, 23, 2, 0)},
+		},
+		{
+			name:    "monthly on dtstart day",
+			spec:    "FREQ=MONTHLY",
+			dtstart: utc(2026, 1, 15, 4, 30),
+			after:   utc(2026, 1, 1, 0, 0),
+			before:  utc(2026, 4, 1, 0, 0),
+			want:    []time.Time{utc(2026, 1, 15, 4, 30), utc(2026, 2, 15, 4, 30), utc(2026, 3, 15, 4, 30)},
+		},
+		{
+			name:    "nothing before dtstart",
+			spec:    "FREQ=DAILY",
+			dtstart: utc(2026, 3, 1, 12, 0),
+			after:   utc(2026, 2, 1, 0, 0),
+			before:  utc(2026, 3, 1, 12, 0),
+			want:    []time.Time{utc(2026, 3, 1, 12, 0)},
+		},
+		{
+			name:    "stops at until",
+			spec:    "FREQ=DAILY;UNTIL=20260303T000000Z",
+			dtstart: utc(2026, 3, 1, 12, 0),
+			after:   utc(2026, 3, 1, 0, 0),
+			before:  utc(2026, 3, 10, 0, 0),
+			want:    []time.Time{utc(2026, 3, 1, 12, 0), utc(2026, 3, 2, 12, 0)},
+		},
+		{
+			name:    "after is exclusive and before inclusive",
+			spec:    "FREQ=DAILY",
+			dtstart: utc(2026, 3, 1, 12, 0),
+			after:   utc(2026, 3, 2, 12, 0),
+			before:  utc(2026, 3, 3, 12, 0),
+			want:    []time.Time{utc(2026, 3, 3, 12, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseRRule(tt.spec)
+			if err != nil {
+				t.Fatalf("parseRRule(%q) failed: %v", tt.spec, err)
+			}
+			got := r.between(tt.dtstart, tt.after, tt.before)
+			if len(got) != len(tt.want) {
+				t.Fatalf("between() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("between()[%d] = %v, want %v", i, got[i].UTC(), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMaintenanceActiveAt(t *testing.T) {
+	utc := func(y int, m time.Month, d, h, min int) time.Time { return time.Date(y, m, d, h, min, 0, 0, time.UTC) }
+	endsAt := utc(2026, 3, 1, 2, 0)
+	longAgo := utc(2020, 1, 1, 0, 0)
+
+	tests := []struct {
+		name   string
+		window models.MaintenanceWindow
+		at     time.Time
+		want   bool
+	}{
+		{
+			name:   "once inside",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceOnce, StartsAt: utc(2026, 3, 1, 0, 0), EndsAt: &endsAt, Active: true},
+			at:     utc(2026, 3, 1, 1, 0),
+			want:   true,
+		},
+		{
+			name:   "once end is exclusive",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceOnce, StartsAt: utc(2026, 3, 1, 0, 0), EndsAt: &endsAt, Active: true},
+			at:     endsAt,
+			want:   false,
+		},
+		{
+			name:   "inactive window",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceOnce, StartsAt: utc(2026, 3, 1, 0, 0), EndsAt: &endsAt},
+			at:     utc(2026, 3, 1, 1, 0),
+			want:   false,
+		},
+		{
+			name:   "before starts_at",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceCron, Recurrence: "0 * * * *", DurationMinutes: 30, Timezone: "UTC", StartsAt: utc(2026, 3, 1, 0, 0), Active: true},
+			at:     utc(2026, 2, 28, 23, 10),
+			want:   false,
+		},
+		{
+			// 09:00 New York is 13:00 UTC once daylight saving time starts on 2026-03-08.
+			name:   "cron after spring forward inside",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceCron, Recurrence: "0 9 * * *", DurationMinutes: 60, Timezone: "America/New_York", StartsAt: longAgo, Active: true},
+			at:     utc(2026, 3, 9, 13, 30),
+			want:   true,
+		},
+		{
+			name:   "cron after spring forward outside",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceCron, Recurrence: "0 9 * * *", DurationMinutes: 60, Timezone: "America/New_York", StartsAt: longAgo, Active: true},
+			at:     utc(2026, 3, 9, 14, 30),
+			want:   false,
+		},
+		{
+			name:   "cron before spring forward inside",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceCron, Recurrence: "0 9 * * *", DurationMinutes: 60, Timezone: "America/New_York", StartsAt: longAgo, Active: true},
+			at:     utc(2026, 3, 6, 14, 30),
+			want:   true,
+		},
+		{
+			name:   "rrule after spring forward inside",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceRRule, Recurrence: "FREQ=DAILY;BYHOUR=9;BYMINUTE=0", DurationMinutes: 60, Timezone: "America/New_York", StartsAt: longAgo, Active: true},
+			at:     utc(2026, 3, 9, 13, 30),
+			want:   true,
+		},
+		{
+			name:   "rrule after spring forward outside",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceRRule, Recurrence: "FREQ=DAILY;BYHOUR=9;BYMINUTE=0", DurationMinutes: 60, Timezone: "America/New_York", StartsAt: longAgo, Active: true},
+			at:     utc(2026, 3, 9, 14, 30),
+			want:   false,
+		},
+		{
+			name:   "cron seven day occurrence last minute",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceCron, Recurrence: "0 0 1 * *", DurationMinutes: maxMaintenanceDurationMinutes, Timezone: "UTC", StartsAt: longAgo, Active: true},
+			at:     utc(2026, 3, 7, 23, 59),
+			want:   true,
+		},
+		{
+			name:   "cron seven day occurrence over",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceCron, Recurrence: "0 0 1 * *", DurationMinutes: maxMaintenanceDurationMinutes, Timezone: "UTC", StartsAt: longAgo, Active: true},
+			at:     utc(2026, 3, 8, 0, 0),
+			want:   false,
+		},
+		{
+			name:   "rrule seven day occurrence last minute",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceRRule, Recurrence: "FREQ=MONTHLY;BYMONTHDAY=1;BYHOUR=0;BYMINUTE=0", DurationMinutes: maxMaintenanceDurationMinutes, Timezone: "UTC", StartsAt: longAgo, Active: true},
+			at:     utc(2026, 3, 7, 23, 59),
+			want:   true,
+		},
+		{
+			name:   "rrule seven day occurrence over",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceRRule, Recurrence: "FREQ=MONTHLY;BYMONTHDAY=1;BYHOUR=0;BYMINUTE=0", DurationMinutes: maxMaintenanceDurationMinutes, Timezone: "UTC", StartsAt: longAgo, Active: true},
+			at:     utc(2026, 3, 8, 0, 0),
+			want:   false,
+		},
+		{
+			name:   "recurring after ends_at",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceCron, Recurrence: "0 * * * *", DurationMinutes: 30, Timezone: "UTC", StartsAt: longAgo, EndsAt: &endsAt, Active: true},
+			at:     utc(2026, 3, 1, 3, 10),
+			want:   false,
+		},
+		{
+			name:   "invalid recurrence",
+			window: models.MaintenanceWindow{RecurrenceType: models.RecurrenceRRule, Recurrence: "FREQ=HOURLY", DurationMinutes: 30, Timezone: "UTC", StartsAt: longAgo, Active: true},
+			at:     utc(2026, 3, 1, 0, 10),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaintenanceActiveAt(&tt.window, tt.at); got != tt.want {
+				t.Errorf("MaintenanceActiveAt() at %v = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
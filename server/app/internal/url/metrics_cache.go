@@ -0,0 +1,107 @@
+package url
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+	"uptimatic/internal/utils"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// monitorCheckTTL drops the last check of monitors that stopped being checked.
+	monitorCheckTTL = 24 * time.Hour
+	// monitorSLATTL outlives a few SLA runs, so the figure disappears soon after a target is removed.
+	monitorSLATTL = time.Hour
+)
+
+// MonitorCheck is the outcome of a monitor's latest check.
+type MonitorCheck struct {
+	Up           bool       `json:"up"`
+	StatusCode   int        `json:"status_code"`
+	ResponseTime int64      `json:"response_time"`
+	CertExpiry   *time.Time `json:"cert_expiry,omitempty"`
+	CheckedAt    time.Time  `json:"checked_at"`
+}
+
+// MonitorSLA is a monitor's error budget as of the latest SLA run.
+type MonitorSLA struct {
+	RemainingBudgetSeconds int64     `json:"remaining_budget_seconds"`
+	BudgetConsumedPercent  float64   `json:"budget_consumed_percent"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// MonitorMetrics is what the cache holds for one monitor; either part is nil when unknown.
+type MonitorMetrics struct {
+	Check *MonitorCheck
+	SLA   *MonitorSLA
+}
+
+// MonitorMetricsCache keeps the latest monitor results in Redis. The worker writes it as checks
+// run, so scrapes never have to touch the status log tables.
+type MonitorMetricsCache interface {
+	SetCheck(ctx context.Context, urlID uint, check *MonitorCheck) error
+	SetSLA(ctx context.Context, urlID uint, sla *MonitorSLA) error
+	GetMany(ctx context.Context, urlIDs []uint) (map[uint]MonitorMetrics, error)
+}
+
+type monitorMetricsCache struct {
+	redis *redis.Client
+}
+
+func NewMonitorMetricsCache(redis *redis.Client) MonitorMetricsCache {
+	return &monitorMetricsCache{redis}
+}
+
+func (c *monitorMetricsCache) SetCheck(ctx context.Context, urlID uint, check *MonitorCheck) error {
+	data, err := json.Marshal(check)
+	if err != nil {
+		return err
+	}
+	return c.redis.Set(ctx, utils.GetMonitorCheckKey(urlID), data, monitorCheckTTL).Err()
+}
+
+func (c *monitorMetricsCache) SetSLA(ctx context.Context, urlID uint, sla *MonitorSLA) error {
+	data, err := json.Marshal(sla)
+	if err != nil {
+		return err
+	}
+	return c.redis.Set(ctx, utils.GetMonitorSLAKey(urlID), data, monitorSLATTL).Err()
+}
+
+func (c *monitorMetricsCache) GetMany(ctx context.Context, urlIDs []uint) (map[uint]MonitorMetrics, error) {
+	result := map[uint]MonitorMetrics{}
+	if len(urlIDs) == 0 {
+		return result, nil
+	}
+
+	keys := make([]string, 0, len(urlIDs)*2)
+	for _, urlID := range urlIDs {
+		keys = append(keys, utils.GetMonitorCheckKey(urlID), utils.GetMonitorSLAKey(urlID))
+	}
+	values, err := c.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, urlID := range urlIDs {
+		var metrics MonitorMetrics
+		if raw, ok := values[i*2].(string); ok {
+			var check MonitorCheck
+			if err := json.Unmarshal([]byte(raw), &check); err == nil {
+				metrics.Check = &check
+			}
+		}
+		if raw, ok := values[i*2+1].(string); ok {
+			var sla MonitorSLA
+			if err := json.Unmarshal([]byte(raw), &sla); err == nil {
+				metrics.SLA = &sla
+			}
+		}
+		if metrics.Check != nil || metrics.SLA != nil {
+			result[urlID] = metrics
+		}
+	}
+	return result, nil
+}
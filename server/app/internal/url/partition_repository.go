@@ -0,0 +1,133 @@
+package url
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StatusLogPartition is one monthly partition of status_logs, covering [Start, End) in UTC.
+type StatusLogPartition struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+type StatusLogPartitionRepository interface {
+	List(ctx context.Context, tx *gorm.DB) ([]StatusLogPartition, error)
+	Create(ctx context.Context, tx *gorm.DB, month time.Time) (*StatusLogPartition, error)
+	Drop(ctx context.Context, tx *gorm.DB, partition *StatusLogPartition) error
+	ListDefaultMonths(ctx context.Context, tx *gorm.DB) ([]StatusLogPartition, error)
+	PruneDefault(ctx context.Context, tx *gorm.DB, partition *StatusLogPartition) error
+}
+
+type statusLogPartitionRepository struct{}
+
+func NewStatusLogPartitionRepository() StatusLogPartitionRepository {
+	return &statusLogPartitionRepository{}
+}
+
+// NewStatusLogPartition returns the partition holding the UTC month of t.
+func NewStatusLogPartition(t time.Time) StatusLogPartition {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return StatusLogPartition{
+		Name:  "status_logs_" + start.Format("200601"),
+		Start: start,
+		End:   start.AddDate(0, 1, 0),
+	}
+}
+
+// List returns the monthly partitions ordered by month; the default partition is left out.
+func (r *statusLogPartitionRepository) List(ctx context.Context, tx *gorm.DB) ([]StatusLogPartition, error) {
+	var names []string
+	err := tx.WithContext(ctx).Raw(`
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		WHERE p.relname = 'status_logs'
+		ORDER BY c.relname;
+	`).Scan(&names).Error
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := []StatusLogPartition{}
+	for _, name := range names {
+		month, err := time.Parse("200601", strings.TrimPrefix(name, "status_logs_"))
+		if err != nil {
+			continue
+		}
+		partitions = append(partitions, NewStatusLogPartition(month))
+	}
+	return partitions, nil
+}
+
+// Create adds the partition for a month. Rows that already landed in the default partition for
+// that month are moved into it, since Postgres refuses to attach a range the default still holds.
+// It must run inside a transaction.
+func (r *statusLogPartitionRepository) Create(ctx context.Context, tx *gorm.DB, month time.Time) (*StatusLogPartition, error) {
+	partition := NewStatusLogPartition(month)
+	args := map[string]any{"start": partition.Start, "end": partition.End}
+	db := tx.WithContext(ctx)
+
+	if err := db.Exec("CREATE TEMP TABLE status_logs_moving (LIKE status_logs) ON COMMIT DROP").Error; err != nil {
+		return nil, err
+	}
+	err := db.Exec(`
+		WITH moved AS (
+			DELETE FROM status_logs_default
+			WHERE checked_at >= @start AND checked_at < @end
+			RETURNING *
+		)
+		INSERT INTO status_logs_moving SELECT * FROM moved;
+	`, args).Error
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("CREATE TABLE %s PARTITION OF status_logs FOR VALUES FROM ('%s') TO ('%s')",
+		partition.Name, partition.Start.Format(time.RFC3339), partition.End.Format(time.RFC3339))
+	if err := db.Exec(query).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Exec("INSERT INTO status_logs SELECT * FROM status_logs_moving").Error; err != nil {
+		return nil, err
+	}
+	return &partition, nil
+}
+
+func (r *statusLogPartitionRepository) Drop(ctx context.Context, tx *gorm.DB, partition *StatusLogPartition) error {
+	return tx.WithContext(ctx).Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", partition.Name)).Error
+}
+
+// ListDefaultMonths returns the months that have rows in the default partition, i.e. logs checked
+// at times no monthly partition covered, ordered by month.
+func (r *statusLogPartitionRepository) ListDefaultMonths(ctx context.Context, tx *gorm.DB) ([]StatusLogPartition, error) {
+	var months []time.Time
+	err := tx.WithContext(ctx).Raw(`
+		SELECT DISTINCT date_trunc('month', checked_at AT TIME ZONE 'UTC') AS month
+		FROM status_logs_default
+		ORDER BY month;
+	`).Scan(&months).Error
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := make([]StatusLogPartition, 0, len(months))
+	for _, month := range months {
+		partitions = append(partitions, NewStatusLogPartition(month))
+	}
+	return partitions, nil
+}
+
+// PruneDefault deletes the rows of a month from the default partition.
+func (r *statusLogPartitionRepository) PruneDefault(ctx context.Context, tx *gorm.DB, partition *StatusLogPartition) error {
+	return tx.WithContext(ctx).
+		Exec("DELETE FROM status_logs_default WHERE checked_at >= ? AND checked_at < ?", partition.Start, partition.End).Error
+}
@@ -2,6 +2,7 @@ package url
 
 import (
 	"context"
+	"time"
 	"uptimatic/internal/models"
 
 	"github.com/google/uuid"
@@ -11,10 +12,19 @@ import (
 type UrlRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, url *models.URL) error
 	Update(ctx context.Context, tx *gorm.DB, url *models.URL) error
+	UpdateLastChecked(ctx context.Context, tx *gorm.DB, id uint, checkedAt time.Time) error
 	Delete(ctx context.Context, tx *gorm.DB, url *models.URL) error
 	FindByPublicID(ctx context.Context, tx *gorm.DB, publicID uuid.UUID) (*models.URL, error)
+	FindByPublicIDAndUserID(ctx context.Context, tx *gorm.DB, userID uint, publicID uuid.UUID) (*models.URL, error)
 	ListByUserID(ctx context.Context, tx *gorm.DB, userID uint, page, perPage int, active *bool, searchLabel string, sortBy string) ([]models.URL, int, error)
+	ListAllByUserID(ctx context.Context, tx *gorm.DB, userID uint) ([]models.URL, error)
 	GetActiveURLs(ctx context.Context, tx *gorm.DB) ([]models.URL, error)
+	ListWithSLA(ctx context.Context, tx *gorm.DB) ([]models.URL, error)
+	ListByPublicIDs(ctx context.Context, tx *gorm.DB, userID uint, publicIDs []uuid.UUID) ([]models.URL, error)
+	ReplaceParents(ctx context.Context, tx *gorm.DB, url *models.URL, parents []models.URL) error
+	ListParentIDs(ctx context.Context, tx *gorm.DB, urlID uint) ([]uint, error)
+	ListDependencies(ctx context.Context, tx *gorm.DB, userID uint) ([]models.URLDependency, error)
+	GetOwnerTimezone(ctx context.Context, tx *gorm.DB, userID uint) (string, error)
 }
 
 type urlRepository struct{}
@@ -31,13 +41,30 @@ func (r *urlRepository) Update(ctx context.Context, tx *gorm.DB, url *models.URL
 	return tx.WithContext(ctx).Save(url).Error
 }
 
+// UpdateLastChecked only touches last_checked, so a check running on a stale copy of the monitor
+// cannot overwrite settings changed while it ran.
+func (r *urlRepository) UpdateLastChecked(ctx context.Context, tx *gorm.DB, id uint, checkedAt time.Time) error {
+	return tx.WithContext(ctx).Model(&models.URL{}).Where("id = ?", id).Update("last_checked", checkedAt).Error
+}
+
 func (r *urlRepository) Delete(ctx context.Context, tx *gorm.DB, url *models.URL) error {
 	return tx.WithContext(ctx).Delete(url).Error
 }
 
 func (r *urlRepository) FindByPublicID(ctx context.Context, tx *gorm.DB, publicID uuid.UUID) (*models.URL, error) {
 	var url models.URL
-	err := tx.WithContext(ctx).First(&url, "public_id = ?", publicID).Error
+	err := tx.WithContext(ctx).Preload("Parents").First(&url, "public_id = ?", publicID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &url, nil
+}
+
+// FindByPublicIDAndUserID finds a monitor owned by userID, so a known public ID alone does not
+// give access to someone else's monitor.
+func (r *urlRepository) FindByPublicIDAndUserID(ctx context.Context, tx *gorm.DB, userID uint, publicID uuid.UUID) (*models.URL, error) {
+	var url models.URL
+	err := tx.WithContext(ctx).Preload("Parents").First(&url, "public_id = ? AND user_id = ?", publicID, userID).Error
 	if err != nil {
 		return nil, err
 	}
@@ -77,13 +104,22 @@ func (r *urlRepository) ListByUserID(
 		query = query.Order("label" + " " + "ASC")
 	}
 
-	if err := query.Offset((page - 1) * perPage).Limit(perPage).Find(&urls).Error; err != nil {
+	if err := query.Preload("Parents").Offset((page - 1) * perPage).Limit(perPage).Find(&urls).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return urls, int(count), nil
 }
 
+func (r *urlRepository) ListAllByUserID(ctx context.Context, tx *gorm.DB, userID uint) ([]models.URL, error) {
+	var urls []models.URL
+	err := tx.WithContext(ctx).Where("user_id = ?", userID).Order("label ASC").Find(&urls).Error
+	if err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
 func (r *urlRepository) GetActiveURLs(ctx context.Context, tx *gorm.DB) ([]models.URL, error) {
 	var urls []models.URL
 	err := tx.WithContext(ctx).Preload("User").Where("active = ?", true).Find(&urls).Error
@@ -92,3 +128,60 @@ func (r *urlRepository) GetActiveURLs(ctx context.Context, tx *gorm.DB) ([]model
 	}
 	return urls, nil
 }
+
+// ListWithSLA returns the active monitors that have an SLA target, with their owners.
+func (r *urlRepository) ListWithSLA(ctx context.Context, tx *gorm.DB) ([]models.URL, error) {
+	var urls []models.URL
+	err := tx.WithContext(ctx).Preload("User").Where("active = ? AND sla_target IS NOT NULL", true).Find(&urls).Error
+	if err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+func (r *urlRepository) ListByPublicIDs(ctx context.Context, tx *gorm.DB, userID uint, publicIDs []uuid.UUID) ([]models.URL, error) {
+	var urls []models.URL
+	err := tx.WithContext(ctx).Where("user_id = ? AND public_id IN ?", userID, publicIDs).Find(&urls).Error
+	if err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+func (r *urlRepository) ReplaceParents(ctx context.Context, tx *gorm.DB, url *models.URL, parents []models.URL) error {
+	if err := tx.WithContext(ctx).Model(url).Association("Parents").Replace(parents); err != nil {
+		return err
+	}
+	url.Parents = parents
+	return nil
+}
+
+func (r *urlRepository) ListParentIDs(ctx context.Context, tx *gorm.DB, urlID uint) ([]uint, error) {
+	var ids []uint
+	err := tx.WithContext(ctx).Model(&models.URLDependency{}).Where("url_id = ?", urlID).Pluck("parent_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *urlRepository) ListDependencies(ctx context.Context, tx *gorm.DB, userID uint) ([]models.URLDependency, error) {
+	var deps []models.URLDependency
+	err := tx.WithContext(ctx).
+		Joins("JOIN urls ON urls.id = url_dependencies.url_id").
+		Where("urls.user_id = ?", userID).
+		Find(&deps).Error
+	if err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+func (r *urlRepository) GetOwnerTimezone(ctx context.Context, tx *gorm.DB, userID uint) (string, error) {
+	var timezone string
+	err := tx.WithContext(ctx).Model(&models.User{}).Select("timezone").Where("id = ?", userID).Scan(&timezone).Error
+	if err != nil {
+		return "", err
+	}
+	return timezone, nil
+}
@@ -0,0 +1,215 @@
+package url
+
+import (
+	"fmt"
+	"strings"
+	"uptimatic/internal/models"
+)
+
+// histogramBounds are the inclusive upper bounds, in milliseconds, of the response time histogram
+// kept in the rollups; a final bucket collects everything slower than the last bound.
+var histogramBounds = []int64{10, 25, 50, 75, 100, 150, 200, 300, 400, 500, 750, 1000, 1500, 2000, 3000, 5000, 7500, 10000, 15000, 30000}
+
+// rollupColumns is the column list shared by both rollup tables and every rollup source query.
+const rollupColumns = `total_checks, up_checks, maintenance_checks, response_time_sum, response_time_count,
+	min_response_time, max_response_time, redirect_checks, client_error_checks, server_error_checks, other_checks,
+	response_time_histogram, dns_time_sum, connect_time_sum, tls_time_sum, ttfb_time_sum, transfer_time_sum, timing_count,
+	dns_checks, connect_checks, tls_checks, timeout_checks`
+
+// rollupAggregatesSQL aggregates raw status logs into rollup columns, in rollupColumns order.
+var rollupAggregatesSQL = `COUNT(*) AS total_checks,
+	COUNT(*) FILTER (WHERE status BETWEEN 200 AND 299 AND NOT in_maintenance) AS up_checks,
+	COUNT(*) FILTER (WHERE in_maintenance) AS maintenance_checks,
+	COALESCE(SUM(response_time) FILTER (WHERE NOT in_maintenance), 0) AS response_time_sum,
+	COUNT(response_time) FILTER (WHERE NOT in_maintenance) AS response_time_count,
+	MIN(response_time) FILTER (WHERE NOT in_maintenance) AS min_response_time,
+	MAX(response_time) FILTER (WHERE NOT in_maintenance) AS max_response_time,
+	COUNT(*) FILTER (WHERE error_kind = 'redirect' AND NOT in_maintenance) AS redirect_checks,
+	COUNT(*) FILTER (WHERE error_kind = 'client_error' AND NOT in_maintenance) AS client_error_checks,
+	COUNT(*) FILTER (WHERE error_kind = 'server_error' AND NOT in_maintenance) AS server_error_checks,
+	COUNT(*) FILTER (WHERE error_kind = 'other' AND NOT in_maintenance) AS other_checks,
+	` + histogramSQL() + ` AS response_time_histogram,
+	COALESCE(SUM(dns_time) FILTER (WHERE NOT in_maintenance), 0) AS dns_time_sum,
+	COALESCE(SUM(connect_time) FILTER (WHERE NOT in_maintenance), 0) AS connect_time_sum,
+	COALESCE(SUM(tls_time) FILTER (WHERE NOT in_maintenance), 0) AS tls_time_sum,
+	COALESCE(SUM(ttfb_time) FILTER (WHERE NOT in_maintenance), 0) AS ttfb_time_sum,
+	COALESCE(SUM(transfer_time) FILTER (WHERE NOT in_maintenance), 0) AS transfer_time_sum,
+	COUNT(ttfb_time) FILTER (WHERE NOT in_maintenance) AS timing_count,
+	COUNT(*) FILTER (WHERE error_kind = 'dns' AND NOT in_maintenance) AS dns_checks,
+	COUNT(*) FILTER (WHERE error_kind = 'connect' AND NOT in_maintenance) AS connect_checks,
+	COUNT(*) FILTER (WHERE error_kind = 'tls' AND NOT in_maintenance) AS tls_checks,
+	COUNT(*) FILTER (WHERE error_kind = 'timeout' AND NOT in_maintenance) AS timeout_checks`
+
+// rollupMergeSQL re-aggregates rollup rows into coarser rollup rows, in rollupColumns order.
+const rollupMergeSQL = `SUM(total_checks) AS total_checks,
+	SUM(up_checks) AS up_checks,
+	SUM(maintenance_checks) AS maintenance_checks,
+	SUM(response_time_sum) AS response_time_sum,
+	SUM(response_time_count) AS response_time_count,
+	MIN(min_response_time) AS min_response_time,
+	MAX(max_response_time) AS max_response_time,
+	SUM(redirect_checks) AS redirect_checks,
+	SUM(client_error_checks) AS client_error_checks,
+	SUM(server_error_checks) AS server_error_checks,
+	SUM(other_checks) AS other_checks,
+	histogram_sum(response_time_histogram) AS response_time_histogram,
+	SUM(dns_time_sum) AS dns_time_sum,
+	SUM(connect_time_sum) AS connect_time_sum,
+	SUM(tls_time_sum) AS tls_time_sum,
+	SUM(ttfb_time_sum) AS ttfb_time_sum,
+	SUM(transfer_time_sum) AS transfer_time_sum,
+	SUM(timing_count) AS timing_count,
+	SUM(dns_checks) AS dns_checks,
+	SUM(connect_checks) AS connect_checks,
+	SUM(tls_checks) AS tls_checks,
+	SUM(timeout_checks) AS timeout_checks`
+
+const rollupUptimeSQL = `COALESCE(ROUND(
+		SUM(up_checks) * 100.0 / NULLIF(SUM(total_checks) - SUM(maintenance_checks), 0),
+		2
+	), 100)`
+
+const rollupWatermarkSQL = `(SELECT COALESCE(MAX(rolled_up_to), '-infinity') FROM status_log_rollup_watermark)`
+
+func histogramSQL() string {
+	parts := make([]string, 0, len(histogramBounds)+1)
+	lower := int64(-1)
+	for _, upper := range histogramBounds {
+		parts = append(parts, fmt.Sprintf("COUNT(*) FILTER (WHERE NOT in_maintenance AND response_time > %d AND response_time <= %d)", lower, upper))
+		lower = upper
+	}
+	parts = append(parts, fmt.Sprintf("COUNT(*) FILTER (WHERE NOT in_maintenance AND response_time > %d)", lower))
+	return "ARRAY[" + strings.Join(parts, ", ") + "]::bigint[]"
+}
+
+// rollupCoverSQL bounds the span [start, end) that rollups answer for: the whole UTC hours inside
+// [@start, @end) that are also before the watermark. The partial hours at either edge are left to the raw logs.
+var rollupCoverSQL = [2]string{
+	`date_trunc('hour', CAST(@start AS timestamptz) + INTERVAL '1 hour' - INTERVAL '1 microsecond', 'UTC')`,
+	fmt.Sprintf(`date_trunc('hour', LEAST(CAST(@end AS timestamptz), %s), 'UTC')`, rollupWatermarkSQL),
+}
+
+// rollupSourceSQL returns rollup shaped rows covering [@start, @end) for the URLs matched by urlFilter.
+// Whole rolled up hours come from the hourly table (or the daily table for whole days when daily is set,
+// which requires @start to be a midnight in @tz that falls on a UTC hour) and everything else, i.e. the
+// partial hours at the edges and anything after the watermark, is aggregated from the raw logs, so
+// readers always see exact and fresh data.
+func rollupSourceSQL(urlFilter string, daily bool) string {
+	coverStart, coverEnd := rollupCoverSQL[0], rollupCoverSQL[1]
+	hourlyFrom := coverStart
+	var b strings.Builder
+
+	if daily {
+		dayBoundary := fmt.Sprintf("LEAST(@end, date_trunc('day', %s, @tz))", rollupWatermarkSQL)
+		fmt.Fprintf(&b, `
+		SELECT url_id, bucket_start, %s
+		FROM status_log_rollups_daily
+		WHERE %s AND bucket_start >= @start AND bucket_start < %s
+		UNION ALL`, rollupColumns, urlFilter, dayBoundary)
+		hourlyFrom = fmt.Sprintf("GREATEST(%s, %s)", coverStart, dayBoundary)
+	}
+
+	fmt.Fprintf(&b, `
+		SELECT url_id, bucket_start, %s
+		FROM status_log_rollups_hourly
+		WHERE %s AND bucket_start >= %s AND bucket_start < %s
+		UNION ALL
+		SELECT url_id, date_trunc('hour', checked_at, 'UTC') AS bucket_start, %s
+		FROM status_logs
+		WHERE %s AND checked_at >= @start AND checked_at < @end
+		AND NOT (checked_at >= %s AND checked_at < %s)
+		GROUP BY url_id, 2`,
+		rollupColumns, urlFilter, hourlyFrom, coverEnd,
+		rollupAggregatesSQL, urlFilter, coverStart, coverEnd)
+
+	return b.String()
+}
+
+// rollupStatFields mirrors statFields for queries over rollups. Percentiles cannot be merged,
+// so they are estimated from the summed histogram instead.
+var rollupStatFields = map[string]string{
+	"min":         "MIN(min_response_time)::float8 AS min_response_time",
+	"avg":         "ROUND(SUM(response_time_sum)::numeric / NULLIF(SUM(response_time_count), 0), 2)::float8 AS avg_response_time",
+	"max":         "MAX(max_response_time)::float8 AS max_response_time",
+	"error_kinds": errorKindCountsSQL("SUM(%s_checks)") + " AS error_kinds",
+	"timings":     timingAveragesSQL("ROUND(SUM(%[1]s_time_sum)::numeric / NULLIF(SUM(timing_count), 0), 2)::float8 AS avg_%[1]s_time"),
+}
+
+var percentileFields = map[string]float64{"p50": 0.5, "p90": 0.9, "p95": 0.95, "p99": 0.99}
+
+func rollupStatFieldsSQL(fields map[string]bool) string {
+	var b strings.Builder
+	histogram := false
+	for _, field := range statFields {
+		if !fields[field.Name] {
+			continue
+		}
+		if _, ok := percentileFields[field.Name]; ok {
+			histogram = true
+			continue
+		}
+		b.WriteString(",\n\t\t\t")
+		b.WriteString(rollupStatFields[field.Name])
+	}
+	if histogram {
+		b.WriteString(",\n\t\t\tMIN(min_response_time)::float8 AS histogram_min,")
+		b.WriteString("\n\t\t\tMAX(max_response_time)::float8 AS histogram_max,")
+		b.WriteString("\n\t\t\thistogram_sum(response_time_histogram) AS response_time_histogram")
+	}
+	return b.String()
+}
+
+// rollupStat is an uptime stat scanned from rollups, with the histogram needed to estimate percentiles.
+type rollupStat struct {
+	models.UptimeStat
+	HistogramMin          *float64
+	HistogramMax          *float64
+	ResponseTimeHistogram models.Histogram
+}
+
+func (r *rollupStat) fillPercentiles(fields map[string]bool) {
+	targets := map[string]**float64{
+		"p50": &r.P50ResponseTime,
+		"p90": &r.P90ResponseTime,
+		"p95": &r.P95ResponseTime,
+		"p99": &r.P99ResponseTime,
+	}
+	for name, q := range percentileFields {
+		if fields[name] {
+			*targets[name] = histogramPercentile(r.ResponseTimeHistogram, q, r.HistogramMin, r.HistogramMax)
+		}
+	}
+}
+
+// histogramPercentile interpolates the q-th percentile inside the histogram bucket that holds it,
+// clamped to the observed min and max.
+func histogramPercentile(histogram []int64, q float64, min, max *float64) *float64 {
+	var total int64
+	for _, count := range histogram {
+		total += count
+	}
+	if total == 0 || min == nil || max == nil {
+		return nil
+	}
+
+	rank := q * float64(total)
+	var seen int64
+	for i, count := range histogram {
+		if count == 0 || float64(seen+count) < rank {
+			seen += count
+			continue
+		}
+
+		lower, upper := *min, *max
+		if i > 0 && float64(histogramBounds[i-1]) > lower {
+			lower = float64(histogramBounds[i-1])
+		}
+		if i < len(histogramBounds) && float64(histogramBounds[i]) < upper {
+			upper = float64(histogramBounds[i])
+		}
+
+		value := lower + (upper-lower)*(rank-float64(seen))/float64(count)
+		return &value
+	}
+	return max
+}
@@ -0,0 +1,151 @@
+package url
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RollupRepository interface {
+	GetWatermark(ctx context.Context, tx *gorm.DB) (*time.Time, error)
+	SetWatermark(ctx context.Context, tx *gorm.DB, rolledUpTo time.Time) error
+	GetFirstCheckedAt(ctx context.Context, tx *gorm.DB) (*time.Time, error)
+	RollupHourly(ctx context.Context, tx *gorm.DB, start, end time.Time) error
+	RollupDaily(ctx context.Context, tx *gorm.DB, start, end time.Time) error
+	RealignDaily(ctx context.Context, tx *gorm.DB) (int64, error)
+	DeleteHourlyBefore(ctx context.Context, tx *gorm.DB, before time.Time) (int64, error)
+}
+
+type rollupRepository struct{}
+
+func NewRollupRepository() RollupRepository {
+	return &rollupRepository{}
+}
+
+// GetWatermark returns the instant up to which status logs have been rolled up, or nil before the first run.
+func (r *rollupRepository) GetWatermark(ctx context.Context, tx *gorm.DB) (*time.Time, error) {
+	var rolledUpTo *time.Time
+	err := tx.WithContext(ctx).Raw("SELECT rolled_up_to FROM status_log_rollup_watermark").Scan(&rolledUpTo).Error
+	if err != nil {
+		return nil, err
+	}
+	return rolledUpTo, nil
+}
+
+func (r *rollupRepository) SetWatermark(ctx context.Context, tx *gorm.DB, rolledUpTo time.Time) error {
+	return tx.WithContext(ctx).Exec("UPDATE status_log_rollup_watermark SET rolled_up_to = ?", rolledUpTo).Error
+}
+
+func (r *rollupRepository) GetFirstCheckedAt(ctx context.Context, tx *gorm.DB) (*time.Time, error) {
+	var checkedAt *time.Time
+	err := tx.WithContext(ctx).Raw("SELECT MIN(checked_at) FROM status_logs").Scan(&checkedAt).Error
+	if err != nil {
+		return nil, err
+	}
+	return checkedAt, nil
+}
+
+// RollupHourly (re)builds the hourly rollups of every URL for the hours in [start, end).
+func (r *rollupRepository) RollupHourly(ctx context.Context, tx *gorm.DB, start, end time.Time) error {
+	query := fmt.Sprintf(`
+		INSERT INTO status_log_rollups_hourly (url_id, bucket_start, %s)
+		SELECT url_id, date_trunc('hour', checked_at, 'UTC') AS bucket_start, %s
+		FROM status_logs
+		WHERE checked_at >= @start AND checked_at < @end
+		GROUP BY url_id, 2
+		ON CONFLICT (url_id, bucket_start) DO UPDATE SET %s;
+	`, rollupColumns, rollupAggregatesSQL, rollupUpsertSQL())
+
+	return tx.WithContext(ctx).Exec(query, map[string]any{"start": start, "end": end}).Error
+}
+
+// RollupDaily (re)builds, from the hourly rollups, every daily rollup whose day touches [start, end).
+// Days are cut at midnight in the owner's time zone.
+func (r *rollupRepository) RollupDaily(ctx context.Context, tx *gorm.DB, start, end time.Time) error {
+	return r.rollupDaily(ctx, tx, "h.bucket_start >= @start AND h.bucket_start < @end", map[string]any{"start": start, "end": end})
+}
+
+// RealignDaily rebuilds the daily rollups of monitors whose owner changed time zone, so their days
+// are cut at the new midnights again. Only days still covered by hourly rollups can be rebuilt; days
+// that ended before the oldest hourly rollup keep the zone they were rolled up in. It returns how many
+// monitors were realigned.
+func (r *rollupRepository) RealignDaily(ctx context.Context, tx *gorm.DB) (int64, error) {
+	var since *time.Time
+	if err := tx.WithContext(ctx).Raw("SELECT MIN(bucket_start) FROM status_log_rollups_hourly").Scan(&since).Error; err != nil {
+		return 0, err
+	}
+	if since == nil {
+		return 0, nil
+	}
+
+	var urlIDs []uint
+	err := tx.WithContext(ctx).Raw(`
+		WITH stale AS (
+			DELETE FROM status_log_rollups_daily d
+			USING urls, users u
+			WHERE urls.id = d.url_id AND u.id = urls.user_id
+			AND d.bucket_start > @since::timestamptz - INTERVAL '1 day'
+			AND d.bucket_start <> date_trunc('day', d.bucket_start, u.timezone)
+			RETURNING d.url_id
+		)
+		SELECT DISTINCT url_id FROM stale;
+	`, map[string]any{"since": *since}).Scan(&urlIDs).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(urlIDs) == 0 {
+		return 0, nil
+	}
+
+	if err := r.rollupDaily(ctx, tx, "h.url_id IN @ids", map[string]any{"ids": urlIDs}); err != nil {
+		return 0, err
+	}
+	return int64(len(urlIDs)), nil
+}
+
+func (r *rollupRepository) rollupDaily(ctx context.Context, tx *gorm.DB, filter string, args map[string]any) error {
+	query := fmt.Sprintf(`
+		WITH days AS (
+			SELECT DISTINCT h.url_id, date_trunc('day', h.bucket_start, u.timezone) AS day_start, u.timezone
+			FROM status_log_rollups_hourly h
+			JOIN urls ON urls.id = h.url_id
+			JOIN users u ON u.id = urls.user_id
+			WHERE %s
+		)
+		INSERT INTO status_log_rollups_daily (url_id, bucket_start, %s)
+		SELECT d.url_id, d.day_start, %s
+		FROM days d
+		JOIN status_log_rollups_hourly ON status_log_rollups_hourly.url_id = d.url_id
+			AND status_log_rollups_hourly.bucket_start >= d.day_start
+			AND status_log_rollups_hourly.bucket_start < ((d.day_start AT TIME ZONE d.timezone) + INTERVAL '1 day') AT TIME ZONE d.timezone
+		GROUP BY d.url_id, d.day_start
+		ON CONFLICT (url_id, bucket_start) DO UPDATE SET %s;
+	`, filter, rollupColumns, rollupMergeSQL, rollupUpsertSQL())
+
+	return tx.WithContext(ctx).Exec(query, args).Error
+}
+
+func (r *rollupRepository) DeleteHourlyBefore(ctx context.Context, tx *gorm.DB, before time.Time) (int64, error) {
+	result := tx.WithContext(ctx).Exec("DELETE FROM status_log_rollups_hourly WHERE bucket_start < ?", before)
+	return result.RowsAffected, result.Error
+}
+
+func rollupUpsertSQL() string {
+	columns := []string{
+		"total_checks", "up_checks", "maintenance_checks", "response_time_sum", "response_time_count",
+		"min_response_time", "max_response_time", "redirect_checks", "client_error_checks",
+		"server_error_checks", "other_checks", "response_time_histogram", "dns_time_sum", "connect_time_sum",
+		"tls_time_sum", "ttfb_time_sum", "transfer_time_sum", "timing_count", "dns_checks", "connect_checks",
+		"tls_checks", "timeout_checks",
+	}
+	set := ""
+	for i, column := range columns {
+		if i > 0 {
+			set += ", "
+		}
+		set += fmt.Sprintf("%s = EXCLUDED.%s", column, column)
+	}
+	return set
+}
@@ -0,0 +1,44 @@
+package url
+
+import "testing"
+
+func TestHistogramPercentile(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+	histogram := func(counts map[int]int64) []int64 {
+		h := make([]int64, len(histogramBounds)+1)
+		for i, count := range counts {
+			h[i] = count
+		}
+		return h
+	}
+
+	tests := []struct {
+		name      string
+		histogram []int64
+		q         float64
+		min, max  *float64
+		want      *float64
+	}{
+		{name: "empty", histogram: histogram(nil), q: 0.5, min: float(1), max: float(2), want: nil},
+		{name: "missing min", histogram: histogram(map[int]int64{2: 10}), q: 0.5, max: float(45), want: nil},
+		{name: "one bucket clamped to observed range", histogram: histogram(map[int]int64{2: 10}), q: 0.5, min: float(30), max: float(45), want: float(37.5)},
+		{name: "one bucket maximum", histogram: histogram(map[int]int64{2: 10}), q: 1, min: float(30), max: float(45), want: float(45)},
+		{name: "end of first bucket", histogram: histogram(map[int]int64{1: 10, 2: 10}), q: 0.5, min: float(12), max: float(40), want: float(25)},
+		{name: "inside second bucket", histogram: histogram(map[int]int64{1: 10, 2: 10}), q: 0.75, min: float(12), max: float(40), want: float(32.5)},
+		{name: "overflow bucket", histogram: histogram(map[int]int64{len(histogramBounds): 4}), q: 0.5, min: float(31000), max: float(35000), want: float(33000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := histogramPercentile(tt.histogram, tt.q, tt.min, tt.max)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("histogramPercentile() = %v, want nil", *got)
+			case tt.want != nil && got == nil:
+				t.Errorf("histogramPercentile() = nil, want %v", *tt.want)
+			case tt.want != nil && *got != *tt.want:
+				t.Errorf("histogramPercentile() = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func UrlRoutes(r *gin.RouterGroup, h URLHandler, jwtUtil *utils.JWTUtil) {
+func UrlRoutes(r *gin.RouterGroup, h URLHandler, mh MaintenanceHandler, bh BadgeHandler, jwtUtil *utils.JWTUtil) {
 	urls := r.Group("/urls")
 	urls.Use(middleware.AuthMiddleware(jwtUtil))
 	urls.Use(middleware.VerifiedMiddleware())
@@ -18,5 +18,19 @@ func UrlRoutes(r *gin.RouterGroup, h URLHandler, jwtUtil *utils.JWTUtil) {
 		urls.PUT("/:id", h.UpdateHandler)
 		urls.DELETE("/:id", h.DeleteHandler)
 		urls.GET("/:id/stats", h.GetUptimeStats)
+		urls.GET("/:id/logs", h.ListLogsHandler)
+		urls.GET("/:id/sla", h.GetSLAHandler)
+
+		urls.POST("/maintenances", mh.CreateHandler)
+		urls.GET("/maintenances", mh.ListHandler)
+		urls.GET("/maintenances/:id", mh.GetHandler)
+		urls.PUT("/maintenances/:id", mh.UpdateHandler)
+		urls.DELETE("/maintenances/:id", mh.DeleteHandler)
+	}
+
+	badges := r.Group("/public/badges")
+	{
+		badges.GET("/:id/:kind", bh.SVGHandler)
+		badges.GET("/:id/:kind/shields", bh.ShieldsHandler)
 	}
 }
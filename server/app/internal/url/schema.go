@@ -10,15 +10,131 @@ type UrlRequest struct {
 	Label string `json:"label" validate:"required"`
 	Url   string `json:"url" validate:"url,required"`
 	// Interval int    `json:"interval" validate:"required"`
-	Active *bool `json:"active" validate:"required"`
+	Active       *bool       `json:"active" validate:"required"`
+	BadgeVisible bool        `json:"badge_visible"`
+	ParentIDs    []uuid.UUID `json:"parent_ids"`
+	// SLATarget is the promised uptime percentage, e.g. 99.9; null disables SLA tracking.
+	SLATarget          *float64 `json:"sla_target" validate:"omitempty,gt=0,lt=100"`
+	SLAWindow          string   `json:"sla_window" validate:"omitempty,oneof=rolling_30d calendar_month"`
+	SLAAlertThresholds []int64  `json:"sla_alert_thresholds" validate:"omitempty,max=10,dive,min=1,max=100"`
 }
 
 type UrlResponse struct {
-	ID          uuid.UUID  `json:"id"`
-	Label       string     `json:"label"`
-	URL         string     `json:"url"`
-	Interval    int        `json:"interval"`
-	Active      bool       `json:"active"`
-	LastChecked *time.Time `json:"last_checked"`
-	CreatedAt   time.Time  `json:"created_at"`
+	ID            uuid.UUID      `json:"id"`
+	Label         string         `json:"label"`
+	URL           string         `json:"url"`
+	Interval      int            `json:"interval"`
+	Active        bool           `json:"active"`
+	BadgeVisible  bool           `json:"badge_visible"`
+	InMaintenance bool           `json:"in_maintenance"`
+	Parents       []UrlReference `json:"parents"`
+	LastChecked   *time.Time     `json:"last_checked"`
+	CreatedAt     time.Time      `json:"created_at"`
+
+	SLATarget          *float64 `json:"sla_target"`
+	SLAWindow          string   `json:"sla_window"`
+	SLAAlertThresholds []int64  `json:"sla_alert_thresholds"`
+}
+
+type UrlReference struct {
+	ID    uuid.UUID `json:"id"`
+	Label string    `json:"label"`
+}
+
+type MaintenanceRequest struct {
+	Title           string      `json:"title" validate:"required"`
+	Description     string      `json:"description"`
+	URLIDs          []uuid.UUID `json:"url_ids" validate:"required,min=1"`
+	StartsAt        time.Time   `json:"starts_at" validate:"required"`
+	EndsAt          *time.Time  `json:"ends_at"`
+	RecurrenceType  string      `json:"recurrence_type" validate:"required,oneof=once cron rrule"`
+	Recurrence      string      `json:"recurrence"`
+	DurationMinutes int         `json:"duration_minutes" validate:"min=0"`
+	Timezone        string      `json:"timezone"`
+	Active          *bool       `json:"active" validate:"required"`
+}
+
+type MaintenanceResponse struct {
+	ID              uuid.UUID   `json:"id"`
+	Title           string      `json:"title"`
+	Description     string      `json:"description"`
+	URLIDs          []uuid.UUID `json:"url_ids"`
+	StartsAt        time.Time   `json:"starts_at"`
+	EndsAt          *time.Time  `json:"ends_at"`
+	RecurrenceType  string      `json:"recurrence_type"`
+	Recurrence      string      `json:"recurrence"`
+	DurationMinutes int         `json:"duration_minutes"`
+	Timezone        string      `json:"timezone"`
+	Active          bool        `json:"active"`
+	InProgress      bool        `json:"in_progress"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+type ShieldsResponse struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+	CacheSeconds  int    `json:"cacheSeconds"`
+}
+
+type UptimeStatsQuery struct {
+	Mode     string
+	Date     string
+	From     string
+	To       string
+	Bucket   string
+	Timezone string
+	Fields   string
+}
+
+type SLAResponse struct {
+	Target                 float64   `json:"target"`
+	Window                 string    `json:"window"`
+	WindowStart            time.Time `json:"window_start"`
+	WindowEnd              time.Time `json:"window_end"`
+	TotalChecks            int       `json:"total_checks"`
+	UpChecks               int       `json:"up_checks"`
+	AchievedUptime         float64   `json:"achieved_uptime"`
+	Met                    bool      `json:"met"`
+	AllowedDowntimeSeconds int64     `json:"allowed_downtime_seconds"`
+	DowntimeSeconds        int64     `json:"downtime_seconds"`
+	RemainingBudgetSeconds int64     `json:"remaining_budget_seconds"`
+	BudgetConsumedPercent  float64   `json:"budget_consumed_percent"`
+	BurnRate               float64   `json:"burn_rate"`
+}
+
+type StatusLogQuery struct {
+	Cursor          string
+	Limit           int
+	Order           string
+	StatusClass     string
+	State           string
+	ErrorKind       string
+	MinResponseTime *int64
+	MaxResponseTime *int64
+}
+
+type StatusLogResponse struct {
+	Status        int       `json:"status"`
+	Up            bool      `json:"up"`
+	ErrorKind     string    `json:"error_kind,omitempty"`
+	ResponseTime  int64     `json:"response_time"`
+	InMaintenance bool      `json:"in_maintenance"`
+	Dependent     bool      `json:"dependent"`
+	Timings       *Timings  `json:"timings,omitempty"`
+	RemoteIP      *string   `json:"remote_ip,omitempty"`
+	Protocol      *string   `json:"protocol,omitempty"`
+	ResponseSize  *int64    `json:"response_size,omitempty"`
+	CheckedAt     time.Time `json:"checked_at"`
+}
+
+// Timings break a check down into phases, in milliseconds. TTFB is counted from the moment the
+// request was sent, so the phases add up to the full download time.
+type Timings struct {
+	DNS      int64 `json:"dns"`
+	Connect  int64 `json:"connect"`
+	TLS      int64 `json:"tls"`
+	TTFB     int64 `json:"ttfb"`
+	Transfer int64 `json:"transfer"`
 }
@@ -4,132 +4,163 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strconv"
 	"time"
+	"uptimatic/internal/db"
 	"uptimatic/internal/models"
 	"uptimatic/internal/utils"
 
+	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type URLService interface {
 	Create(ctx context.Context, url *UrlRequest, userID uint) (*UrlResponse, *utils.AppError)
-	Update(ctx context.Context, url *UrlRequest, id uuid.UUID) (*UrlResponse, *utils.AppError)
-	Delete(ctx context.Context, id uuid.UUID) *utils.AppError
-	FindByID(ctx context.Context, id uuid.UUID) (*UrlResponse, *utils.AppError)
+	Update(ctx context.Context, url *UrlRequest, userID uint, id uuid.UUID) (*UrlResponse, *utils.AppError)
+	Delete(ctx context.Context, userID uint, id uuid.UUID) *utils.AppError
+	FindByID(ctx context.Context, userID uint, id uuid.UUID) (*UrlResponse, *utils.AppError)
 	ListByUserID(ctx context.Context, userID uint, page, perPage int, active *bool, searchLabel string, sortBy string) ([]UrlResponse, int, *utils.AppError)
-	GetUptimeStats(ctx context.Context, urlID uuid.UUID, mode, dateStr string) ([]models.UptimeStat, *utils.AppError)
+	GetUptimeStats(ctx context.Context, userID uint, urlID uuid.UUID, query *UptimeStatsQuery) ([]models.UptimeStat, *utils.AppError)
+	ListLogs(ctx context.Context, userID uint, urlID uuid.UUID, query *StatusLogQuery) ([]StatusLogResponse, string, *utils.AppError)
+	GetSLA(ctx context.Context, userID uint, urlID uuid.UUID) (*SLAResponse, *utils.AppError)
+}
+
+// LogArchiver copies a monitor's status logs to the storage bucket before they are deleted,
+// recording the archives through the transaction that deletes the monitor.
+type LogArchiver interface {
+	ArchiveURL(ctx context.Context, tx *gorm.DB, u *models.URL) error
 }
 
 type urlService struct {
-	db            *gorm.DB
-	urlRepo       UrlRepository
-	statusLogRepo StatusLogRepository
+	db              *gorm.DB
+	redis           *redis.Client
+	urlRepo         UrlRepository
+	statusLogRepo   StatusLogRepository
+	maintenanceRepo MaintenanceRepository
+	archiver        LogArchiver
 }
 
-func NewUrlService(db *gorm.DB, urlRepo UrlRepository, statusLogRepo StatusLogRepository) URLService {
-	return &urlService{db, urlRepo, statusLogRepo}
+func NewUrlService(db *gorm.DB, redis *redis.Client, urlRepo UrlRepository, statusLogRepo StatusLogRepository, maintenanceRepo MaintenanceRepository, archiver LogArchiver) URLService {
+	return &urlService{db, redis, urlRepo, statusLogRepo, maintenanceRepo, archiver}
 }
 
 func (s *urlService) Create(ctx context.Context, url *UrlRequest, userID uint) (*UrlResponse, *utils.AppError) {
 	utils.Info(ctx, "Creating new URL", map[string]any{"user_id": userID, "label": url.Label, "url": url.Url})
 
 	urlModel := &models.URL{
-		UserID:   userID,
-		PublicID: uuid.New(),
-		Label:    url.Label,
-		URL:      url.Url,
-		Interval: 300,
-		Active:   *url.Active,
+		UserID:       userID,
+		PublicID:     uuid.New(),
+		Label:        url.Label,
+		URL:          url.Url,
+		Interval:     300,
+		Active:       *url.Active,
+		BadgeVisible: url.BadgeVisible,
 	}
+	applySLA(urlModel, url)
 
-	err := s.urlRepo.Create(ctx, s.db, urlModel)
+	parents, appErr := s.resolveParents(ctx, urlModel, url.ParentIDs)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	err := db.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.urlRepo.Create(ctx, tx, urlModel); err != nil {
+			return err
+		}
+		return s.urlRepo.ReplaceParents(ctx, tx, urlModel, parents)
+	})
 	if err != nil {
 		utils.Error(ctx, "Failed to create URL", map[string]any{"user_id": userID, "err": err.Error()})
 		return nil, utils.InternalServerError("Error creating url", err)
 	}
 
 	utils.Info(ctx, "URL created successfully", map[string]any{"url_id": urlModel.ID, "user_id": userID})
-	return &UrlResponse{
-		ID:          urlModel.PublicID,
-		Label:       urlModel.Label,
-		URL:         urlModel.URL,
-		Interval:    urlModel.Interval,
-		Active:      urlModel.Active,
-		LastChecked: urlModel.LastChecked,
-		CreatedAt:   urlModel.CreatedAt,
-	}, nil
+	inMaintenance := s.maintenanceURLIDs(ctx, urlModel.ID)
+	response := newUrlResponse(urlModel, inMaintenance[urlModel.ID])
+	return &response, nil
 }
 
-func (s *urlService) Update(ctx context.Context, url *UrlRequest, id uuid.UUID) (*UrlResponse, *utils.AppError) {
-	utils.Info(ctx, "Updating URL", map[string]any{"url_id": id})
+func (s *urlService) Update(ctx context.Context, url *UrlRequest, userID uint, id uuid.UUID) (*UrlResponse, *utils.AppError) {
+	utils.Info(ctx, "Updating URL", map[string]any{"url_id": id, "user_id": userID})
 
-	urlModel, err := s.urlRepo.FindByPublicID(ctx, s.db, id)
+	urlModel, err := s.urlRepo.FindByPublicIDAndUserID(ctx, s.db, userID, id)
 	if err != nil {
-		utils.Error(ctx, "URL not found for update", map[string]any{"url_id": id, "err": err.Error()})
-		return nil, utils.InternalServerError("Error finding url", err)
+		utils.Warn(ctx, "URL not found for update", map[string]any{"url_id": id, "user_id": userID})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Url not found", err)
+	}
+
+	parents, appErr := s.resolveParents(ctx, urlModel, url.ParentIDs)
+	if appErr != nil {
+		return nil, appErr
 	}
 
 	urlModel.Label = url.Label
 	urlModel.URL = url.Url
 	urlModel.Active = *url.Active
+	urlModel.BadgeVisible = url.BadgeVisible
+	urlModel.Parents = nil
+	applySLA(urlModel, url)
 
-	err = s.urlRepo.Update(ctx, s.db, urlModel)
+	err = db.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.urlRepo.Update(ctx, tx, urlModel); err != nil {
+			return err
+		}
+		return s.urlRepo.ReplaceParents(ctx, tx, urlModel, parents)
+	})
 	if err != nil {
 		utils.Error(ctx, "Failed to update URL", map[string]any{"url_id": id, "err": err.Error()})
 		return nil, utils.InternalServerError("Error updating url", err)
 	}
 
+	invalidateBadges(ctx, s.redis, urlModel.PublicID)
+
 	utils.Info(ctx, "URL updated successfully", map[string]any{"url_id": id})
-	return &UrlResponse{
-		ID:          urlModel.PublicID,
-		Label:       urlModel.Label,
-		URL:         urlModel.URL,
-		Interval:    urlModel.Interval,
-		Active:      urlModel.Active,
-		LastChecked: urlModel.LastChecked,
-		CreatedAt:   urlModel.CreatedAt,
-	}, nil
+	inMaintenance := s.maintenanceURLIDs(ctx, urlModel.ID)
+	response := newUrlResponse(urlModel, inMaintenance[urlModel.ID])
+	return &response, nil
 }
 
-func (s *urlService) Delete(ctx context.Context, id uuid.UUID) *utils.AppError {
-	utils.Info(ctx, "Deleting URL", map[string]any{"url_id": id})
+func (s *urlService) Delete(ctx context.Context, userID uint, id uuid.UUID) *utils.AppError {
+	utils.Info(ctx, "Deleting URL", map[string]any{"url_id": id, "user_id": userID})
 
-	urlModel, err := s.urlRepo.FindByPublicID(ctx, s.db, id)
+	urlModel, err := s.urlRepo.FindByPublicIDAndUserID(ctx, s.db, userID, id)
 	if err != nil {
-		utils.Warn(ctx, "URL not found for deletion", map[string]any{"url_id": id})
+		utils.Warn(ctx, "URL not found for deletion", map[string]any{"url_id": id, "user_id": userID})
 		return utils.NewAppError(http.StatusNotFound, utils.NotFound, "Url not found", err)
 	}
 
-	err = s.urlRepo.Delete(ctx, s.db, urlModel)
+	// Status logs cascade with the monitor, so they are archived first or they would be lost.
+	err = db.WithTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.archiver.ArchiveURL(ctx, tx, urlModel); err != nil {
+			return err
+		}
+		return s.urlRepo.Delete(ctx, tx, urlModel)
+	})
 	if err != nil {
 		utils.Error(ctx, "Failed to delete URL", map[string]any{"url_id": id, "err": err.Error()})
 		return utils.InternalServerError("Error deleting url", err)
 	}
 
+	invalidateBadges(ctx, s.redis, urlModel.PublicID)
+
 	utils.Info(ctx, "URL deleted successfully", map[string]any{"url_id": id})
 	return nil
 }
 
-func (s *urlService) FindByID(ctx context.Context, id uuid.UUID) (*UrlResponse, *utils.AppError) {
-	utils.Info(ctx, "Fetching URL by ID", map[string]any{"url_id": id})
+func (s *urlService) FindByID(ctx context.Context, userID uint, id uuid.UUID) (*UrlResponse, *utils.AppError) {
+	utils.Info(ctx, "Fetching URL by ID", map[string]any{"url_id": id, "user_id": userID})
 
-	urlModel, err := s.urlRepo.FindByPublicID(ctx, s.db, id)
+	urlModel, err := s.urlRepo.FindByPublicIDAndUserID(ctx, s.db, userID, id)
 	if err != nil {
-		utils.Warn(ctx, "URL not found", map[string]any{"url_id": id})
+		utils.Warn(ctx, "URL not found", map[string]any{"url_id": id, "user_id": userID})
 		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Url not found", err)
 	}
 
 	utils.Info(ctx, "URL fetched successfully", map[string]any{"url_id": id})
-	return &UrlResponse{
-		ID:          urlModel.PublicID,
-		Label:       urlModel.Label,
-		URL:         urlModel.URL,
-		Interval:    urlModel.Interval,
-		Active:      urlModel.Active,
-		LastChecked: urlModel.LastChecked,
-		CreatedAt:   urlModel.CreatedAt,
-	}, nil
+	inMaintenance := s.maintenanceURLIDs(ctx, urlModel.ID)
+	response := newUrlResponse(urlModel, inMaintenance[urlModel.ID])
+	return &response, nil
 }
 
 func (s *urlService) ListByUserID(ctx context.Context, userID uint, page, perPage int, active *bool, searchLabel string, sortBy string) ([]UrlResponse, int, *utils.AppError) {
@@ -141,17 +172,15 @@ func (s *urlService) ListByUserID(ctx context.Context, userID uint, page, perPag
 		return nil, 0, utils.InternalServerError("Error listing urls", err)
 	}
 
-	var responses []UrlResponse
+	urlIDs := make([]uint, 0, len(urls))
 	for _, url := range urls {
-		responses = append(responses, UrlResponse{
-			ID:          url.PublicID,
-			Label:       url.Label,
-			URL:         url.URL,
-			Interval:    url.Interval,
-			Active:      url.Active,
-			LastChecked: url.LastChecked,
-			CreatedAt:   url.CreatedAt,
-		})
+		urlIDs = append(urlIDs, url.ID)
+	}
+	inMaintenance := s.maintenanceURLIDs(ctx, urlIDs...)
+
+	var responses []UrlResponse
+	for i := range urls {
+		responses = append(responses, newUrlResponse(&urls[i], inMaintenance[urls[i].ID]))
 	}
 
 	if len(responses) == 0 {
@@ -163,68 +192,65 @@ func (s *urlService) ListByUserID(ctx context.Context, userID uint, page, perPag
 	return responses, count, nil
 }
 
-func (s *urlService) GetUptimeStats(ctx context.Context, urlID uuid.UUID, mode string, dateStr string) ([]models.UptimeStat, *utils.AppError) {
-	url, err := s.urlRepo.FindByPublicID(ctx, s.db, urlID)
+func (s *urlService) GetUptimeStats(ctx context.Context, userID uint, urlID uuid.UUID, query *UptimeStatsQuery) ([]models.UptimeStat, *utils.AppError) {
+	url, err := s.urlRepo.FindByPublicIDAndUserID(ctx, s.db, userID, urlID)
 	if err != nil {
-		utils.Warn(ctx, "URL not found", map[string]any{"url_id": urlID})
+		utils.Warn(ctx, "URL not found", map[string]any{"url_id": urlID, "user_id": userID})
 		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Url not found", err)
 	}
 
-	loc, err := time.LoadLocation("Asia/Jakarta")
+	fields, err := ParseStatFields(query.Fields)
 	if err != nil {
-		utils.Error(ctx, "Failed to load location", map[string]any{"err": err.Error()})
-		return nil, utils.InternalServerError("Error loading location", err)
+		utils.Warn(ctx, "Invalid stats fields", map[string]any{"fields": query.Fields})
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err)
 	}
 
-	// Default: pakai tanggal hari ini kalau user tidak kasih parameter
-	var targetDate time.Time
-	if dateStr == "" {
-		targetDate = time.Now().In(loc)
-	} else {
-		targetDate, err = time.ParseInLocation("2006-01-02", dateStr, loc)
-		if err != nil {
-			utils.Warn(ctx, "Invalid date format", map[string]any{"date": dateStr})
-			return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid date format (use YYYY-MM-DD)", err)
-		}
+	ownerTimezone, err := s.urlRepo.GetOwnerTimezone(ctx, s.db, url.UserID)
+	if err != nil {
+		utils.Error(ctx, "Failed to get user timezone", map[string]any{"user_id": url.UserID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading timezone", err)
+	}
+	if ownerTimezone == "" {
+		ownerTimezone = utils.DefaultTimezone
 	}
 
-	var truncUnit string
-	var startLocal, endLocal time.Time
-
-	switch mode {
-	case "day":
-		truncUnit = "hour"
-		startLocal = time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), 0, 0, 0, 0, loc)
-		endLocal = startLocal.Add(24 * time.Hour)
-
-	case "month":
-		truncUnit = "day"
-		startLocal = time.Date(targetDate.Year(), targetDate.Month(), 1, 0, 0, 0, 0, loc)
-		endLocal = startLocal.AddDate(0, 1, 0)
+	timezone := query.Timezone
+	if timezone == "" {
+		timezone = ownerTimezone
+	}
 
-	case "year":
-		truncUnit = "month"
-		startLocal = time.Date(targetDate.Year(), 1, 1, 0, 0, 0, 0, loc)
-		endLocal = startLocal.AddDate(1, 0, 0)
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		utils.Warn(ctx, "Invalid timezone", map[string]any{"tz": timezone})
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid timezone", err)
+	}
 
-	default:
-		utils.Warn(ctx, "Invalid mode for uptime stats", map[string]any{"mode": mode})
-		return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid mode", nil)
+	start, end, bucket, appErr := statsRange(query, loc)
+	if appErr != nil {
+		utils.Warn(ctx, "Invalid stats range", map[string]any{"url_id": urlID, "err": appErr.Message})
+		return nil, appErr
 	}
 
-	start := startLocal.UTC()
-	end := endLocal.UTC()
+	starts, err := bucketStarts(bucket, start, end, loc)
+	if err != nil {
+		utils.Warn(ctx, "Stats range exceeds max points", map[string]any{"url_id": urlID, "bucket": bucket})
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Too many data points, use a larger bucket or a shorter range", err)
+	}
 
 	utils.Info(ctx, "Fetching uptime stats", map[string]any{
-		"url_id":      urlID,
-		"mode":        mode,
-		"date":        targetDate.Format("2006-01-02"),
-		"trunc_unit":  truncUnit,
-		"start_local": startLocal,
-		"end_local":   endLocal,
+		"url_id": urlID,
+		"bucket": bucket,
+		"tz":     timezone,
+		"from":   start,
+		"to":     end,
 	})
 
-	stats, err := s.statusLogRepo.GetUptimeStats(ctx, s.db, url.ID, truncUnit, start, end)
+	// Daily rollups are cut at the owner's midnights, so they only line up with whole days in that zone,
+	// and they are built from UTC hours, so only in zones whose midnights fall on a UTC hour.
+	daily := statBuckets[bucket].Unit != "" && timezone == ownerTimezone && isMidnight(start, loc) && isMidnight(end, loc) &&
+		start.Truncate(time.Hour).Equal(start)
+
+	stats, err := s.statusLogRepo.GetUptimeStats(ctx, s.db, url.ID, bucket, timezone, start.UTC(), end.UTC(), fields, daily)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return []models.UptimeStat{}, nil
@@ -233,5 +259,307 @@ func (s *urlService) GetUptimeStats(ctx context.Context, urlID uuid.UUID, mode s
 		return nil, utils.InternalServerError("Error getting uptime stats", err)
 	}
 
-	return stats, nil
+	byStart := map[int64]models.UptimeStat{}
+	for _, stat := range stats {
+		byStart[stat.BucketStart.Unix()] = stat
+	}
+
+	filled := make([]models.UptimeStat, 0, len(starts))
+	for _, bucketStart := range starts {
+		stat, ok := byStart[bucketStart.Unix()]
+		if !ok {
+			stat = models.UptimeStat{}
+		}
+		stat.BucketStart = bucketStart.In(loc)
+		filled = append(filled, stat)
+	}
+
+	return filled, nil
+}
+
+func (s *urlService) ListLogs(ctx context.Context, userID uint, urlID uuid.UUID, query *StatusLogQuery) ([]StatusLogResponse, string, *utils.AppError) {
+	url, err := s.urlRepo.FindByPublicIDAndUserID(ctx, s.db, userID, urlID)
+	if err != nil {
+		utils.Warn(ctx, "URL not found", map[string]any{"url_id": urlID, "user_id": userID})
+		return nil, "", utils.NewAppError(http.StatusNotFound, utils.NotFound, "Url not found", err)
+	}
+
+	filter := &StatusLogFilter{
+		Ascending:       query.Order == "asc",
+		StatusClass:     query.StatusClass,
+		State:           query.State,
+		ErrorKind:       query.ErrorKind,
+		MinResponseTime: query.MinResponseTime,
+		MaxResponseTime: query.MaxResponseTime,
+		Limit:           query.Limit + 1,
+	}
+	if query.Cursor != "" {
+		checkedAt, id, err := utils.DecodeCursor(query.Cursor)
+		if err != nil {
+			utils.Warn(ctx, "Invalid log cursor", map[string]any{"url_id": urlID})
+			return nil, "", utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid cursor", err)
+		}
+		filter.CursorCheckedAt = &checkedAt
+		filter.CursorID = id
+	}
+
+	logs, err := s.statusLogRepo.ListByURLID(ctx, s.db, url.ID, filter)
+	if err != nil {
+		utils.Error(ctx, "Failed to list status logs", map[string]any{"url_id": urlID, "err": err.Error()})
+		return nil, "", utils.InternalServerError("Error listing logs", err)
+	}
+
+	nextCursor := ""
+	if len(logs) > query.Limit {
+		logs = logs[:query.Limit]
+		last := logs[len(logs)-1]
+		nextCursor = utils.EncodeCursor(last.CheckedAt, last.ID)
+	}
+
+	responses := make([]StatusLogResponse, 0, len(logs))
+	for _, log := range logs {
+		status, _ := strconv.Atoi(log.Status)
+		responses = append(responses, StatusLogResponse{
+			Status:        status,
+			Up:            !IsDown(status),
+			ErrorKind:     LogErrorKind(&log),
+			ResponseTime:  log.ResponseTime,
+			InMaintenance: log.InMaintenance,
+			Dependent:     log.Dependent,
+			Timings:       logTimings(&log),
+			RemoteIP:      log.RemoteIP,
+			Protocol:      log.Protocol,
+			ResponseSize:  log.ResponseSize,
+			CheckedAt:     log.CheckedAt,
+		})
+	}
+
+	return responses, nextCursor, nil
+}
+
+func (s *urlService) GetSLA(ctx context.Context, userID uint, urlID uuid.UUID) (*SLAResponse, *utils.AppError) {
+	url, err := s.urlRepo.FindByPublicIDAndUserID(ctx, s.db, userID, urlID)
+	if err != nil {
+		utils.Warn(ctx, "URL not found", map[string]any{"url_id": urlID, "user_id": userID})
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Url not found", err)
+	}
+	if url.SLATarget == nil {
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Url has no SLA target", nil)
+	}
+
+	timezone, err := s.urlRepo.GetOwnerTimezone(ctx, s.db, url.UserID)
+	if err != nil {
+		utils.Error(ctx, "Failed to get user timezone", map[string]any{"user_id": url.UserID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error loading timezone", err)
+	}
+	if timezone == "" {
+		timezone = utils.DefaultTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc, _ = time.LoadLocation(utils.DefaultTimezone)
+	}
+
+	now := time.Now()
+	start, end := SLAWindowRange(url.SLAWindow, now, loc)
+	summary, err := s.statusLogRepo.GetUptimeSummary(ctx, s.db, url.ID, start.UTC())
+	if err != nil {
+		utils.Error(ctx, "Failed to get uptime summary", map[string]any{"url_id": urlID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error getting SLA", err)
+	}
+
+	response := NewSLAResponse(url, summary, start.In(loc), end.In(loc), now)
+	return &response, nil
+}
+
+// applySLA copies the SLA settings of a request onto a monitor.
+func applySLA(url *models.URL, req *UrlRequest) {
+	url.SLATarget = req.SLATarget
+	url.SLAWindow = req.SLAWindow
+	if url.SLAWindow == "" {
+		url.SLAWindow = models.SLAWindowRolling30d
+	}
+	url.SLAAlertThresholds = normalizeSLAThresholds(req.SLAAlertThresholds)
+}
+
+// statsRange resolves the requested window and bucket, preferring from/to and falling back to mode/date.
+func statsRange(query *UptimeStatsQuery, loc *time.Location) (time.Time, time.Time, string, *utils.AppError) {
+	var start, end time.Time
+	bucket := query.Bucket
+
+	if query.From != "" || query.To != "" {
+		if query.From == "" {
+			return start, end, "", utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "from is required when to is set", nil)
+		}
+
+		var err error
+		start, err = time.Parse(time.RFC3339, query.From)
+		if err != nil {
+			return start, end, "", utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid from (use RFC3339)", err)
+		}
+		end = time.Now()
+		if query.To != "" {
+			end, err = time.Parse(time.RFC3339, query.To)
+			if err != nil {
+				return start, end, "", utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid to (use RFC3339)", err)
+			}
+		}
+		if !end.After(start) {
+			return start, end, "", utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "to must be after from", nil)
+		}
+
+		if bucket == "" {
+			switch span := end.Sub(start); {
+			case span <= 7*24*time.Hour:
+				bucket = "1h"
+			case span <= 366*24*time.Hour:
+				bucket = "1d"
+			default:
+				bucket = "1M"
+			}
+		}
+	} else {
+		// Default: pakai tanggal hari ini kalau user tidak kasih parameter
+		targetDate := time.Now().In(loc)
+		if query.Date != "" {
+			var err error
+			targetDate, err = time.ParseInLocation("2006-01-02", query.Date, loc)
+			if err != nil {
+				return start, end, "", utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid date format (use YYYY-MM-DD)", err)
+			}
+		}
+
+		switch query.Mode {
+		case "day":
+			start = time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), 0, 0, 0, 0, loc)
+			end = start.AddDate(0, 0, 1)
+		case "month":
+			start = time.Date(targetDate.Year(), targetDate.Month(), 1, 0, 0, 0, 0, loc)
+			end = start.AddDate(0, 1, 0)
+		case "year":
+			start = time.Date(targetDate.Year(), 1, 1, 0, 0, 0, 0, loc)
+			end = start.AddDate(1, 0, 0)
+		default:
+			return start, end, "", utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid mode", nil)
+		}
+
+		if bucket == "" {
+			bucket = modeBuckets[query.Mode]
+		}
+	}
+
+	if _, ok := statBuckets[bucket]; !ok {
+		return start, end, "", utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid bucket (use 1m, 5m, 1h, 1d, 1w or 1M)", nil)
+	}
+	return start, end, bucket, nil
+}
+
+// maintenanceURLIDs returns which of the given URLs are covered by a maintenance window right now.
+func (s *urlService) maintenanceURLIDs(ctx context.Context, urlIDs ...uint) map[uint]bool {
+	windows, err := s.maintenanceRepo.ListActiveByURLIDs(ctx, s.db, urlIDs)
+	if err != nil {
+		utils.Error(ctx, "Failed to load maintenance windows", map[string]any{"err": err.Error()})
+		return map[uint]bool{}
+	}
+	return MaintenanceURLIDs(windows, time.Now())
+}
+
+// resolveParents loads the requested parent monitors and rejects foreign monitors and dependency cycles.
+func (s *urlService) resolveParents(ctx context.Context, url *models.URL, parentIDs []uuid.UUID) ([]models.URL, *utils.AppError) {
+	if len(parentIDs) == 0 {
+		return []models.URL{}, nil
+	}
+
+	parents, err := s.urlRepo.ListByPublicIDs(ctx, s.db, url.UserID, parentIDs)
+	if err != nil {
+		utils.Error(ctx, "Failed to resolve parent URLs", map[string]any{"user_id": url.UserID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error finding parent urls", err)
+	}
+	if len(parents) != len(parentIDs) {
+		return nil, utils.NewAppError(http.StatusNotFound, utils.NotFound, "Parent url not found", nil)
+	}
+
+	if url.ID == 0 {
+		return parents, nil
+	}
+
+	deps, err := s.urlRepo.ListDependencies(ctx, s.db, url.UserID)
+	if err != nil {
+		utils.Error(ctx, "Failed to list URL dependencies", map[string]any{"user_id": url.UserID, "err": err.Error()})
+		return nil, utils.InternalServerError("Error listing url dependencies", err)
+	}
+
+	graph := map[uint][]uint{}
+	for _, dep := range deps {
+		if dep.URLID != url.ID {
+			graph[dep.URLID] = append(graph[dep.URLID], dep.ParentID)
+		}
+	}
+	for _, parent := range parents {
+		graph[url.ID] = append(graph[url.ID], parent.ID)
+	}
+
+	if hasDependencyCycle(graph, url.ID) {
+		utils.Warn(ctx, "Rejected URL dependency cycle", map[string]any{"url_id": url.PublicID})
+		return nil, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Dependency cycle detected", nil)
+	}
+
+	return parents, nil
+}
+
+// hasDependencyCycle reports whether start can reach itself by following parent edges.
+func hasDependencyCycle(graph map[uint][]uint, start uint) bool {
+	visited := map[uint]bool{}
+	stack := append([]uint{}, graph[start]...)
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == start {
+			return true
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		stack = append(stack, graph[id]...)
+	}
+	return false
+}
+
+func newUrlResponse(url *models.URL, inMaintenance bool) UrlResponse {
+	parents := []UrlReference{}
+	for _, parent := range url.Parents {
+		parents = append(parents, UrlReference{ID: parent.PublicID, Label: parent.Label})
+	}
+
+	return UrlResponse{
+		ID:            url.PublicID,
+		Label:         url.Label,
+		URL:           url.URL,
+		Interval:      url.Interval,
+		Active:        url.Active,
+		BadgeVisible:  url.BadgeVisible,
+		InMaintenance: inMaintenance,
+		Parents:       parents,
+		LastChecked:   url.LastChecked,
+		CreatedAt:     url.CreatedAt,
+
+		SLATarget:          url.SLATarget,
+		SLAWindow:          url.SLAWindow,
+		SLAAlertThresholds: url.SLAAlertThresholds,
+	}
+}
+
+// logTimings returns the phase timings of a check, or nil for checks recorded without them.
+func logTimings(log *models.StatusLog) *Timings {
+	if log.DNSTime == nil || log.ConnectTime == nil || log.TLSTime == nil || log.TTFBTime == nil || log.TransferTime == nil {
+		return nil
+	}
+	return &Timings{
+		DNS:      *log.DNSTime,
+		Connect:  *log.ConnectTime,
+		TLS:      *log.TLSTime,
+		TTFB:     *log.TTFBTime,
+		Transfer: *log.TransferTime,
+	}
 }
@@ -0,0 +1,29 @@
+package url
+
+import "testing"
+
+func TestHasDependencyCycle(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph map[uint][]uint
+		start uint
+		want  bool
+	}{
+		{name: "no parents", graph: map[uint][]uint{}, start: 1, want: false},
+		{name: "chain", graph: map[uint][]uint{1: {2}, 2: {3}}, start: 1, want: false},
+		{name: "self parent", graph: map[uint][]uint{1: {1}}, start: 1, want: true},
+		{name: "two node cycle", graph: map[uint][]uint{1: {2}, 2: {1}}, start: 1, want: true},
+		{name: "long cycle", graph: map[uint][]uint{1: {2}, 2: {3}, 3: {4}, 4: {1}}, start: 1, want: true},
+		{name: "diamond", graph: map[uint][]uint{1: {2, 3}, 2: {4}, 3: {4}}, start: 1, want: false},
+		{name: "cycle not through start", graph: map[uint][]uint{1: {2}, 2: {3}, 3: {2}}, start: 1, want: false},
+		{name: "cycle through later parent", graph: map[uint][]uint{1: {2, 3}, 3: {5}, 5: {1}}, start: 1, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasDependencyCycle(tt.graph, tt.start); got != tt.want {
+				t.Errorf("hasDependencyCycle(%v, %d) = %v, want %v", tt.graph, tt.start, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package url
+
+import (
+	"math"
+	"sort"
+	"time"
+	"uptimatic/internal/models"
+)
+
+// slaRollingWindow is the length of the rolling_30d SLA window.
+const slaRollingWindow = 30 * 24 * time.Hour
+
+// DefaultSLAAlertThresholds are the error budget percentages alerted on when a monitor sets none.
+var DefaultSLAAlertThresholds = models.Int64Array{50, 75, 100}
+
+// SLAWindowRange returns the SLA window that contains now. Calendar months are cut at midnight in loc.
+func SLAWindowRange(window string, now time.Time, loc *time.Location) (time.Time, time.Time) {
+	if window == models.SLAWindowCalendarMonth {
+		local := now.In(loc)
+		start := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 1, 0)
+	}
+	return now.Add(-slaRollingWindow), now
+}
+
+// NewSLAResponse turns the uptime achieved so far in a window into error budget figures.
+// The budget covers the whole window, while downtime is measured over the part of it already
+// elapsed (and not before the monitor existed), so a calendar month starts with its full budget.
+// Burn rate is the failure rate relative to the rate the target allows; above 1 the budget runs
+// out before the window ends.
+func NewSLAResponse(url *models.URL, summary *models.UptimeSummary, start, end, now time.Time) SLAResponse {
+	target := *url.SLATarget
+
+	measuredFrom := start
+	if url.CreatedAt.After(measuredFrom) {
+		measuredFrom = url.CreatedAt
+	}
+	measured := now.Sub(measuredFrom)
+	if measured < 0 {
+		measured = 0
+	}
+
+	failureRate := 1 - summary.UptimePercent/100
+	allowedRate := 1 - target/100
+	allowed := time.Duration(allowedRate * float64(end.Sub(start)))
+	downtime := time.Duration(failureRate * float64(measured))
+
+	response := SLAResponse{
+		Target:                 target,
+		Window:                 url.SLAWindow,
+		WindowStart:            start,
+		WindowEnd:              end,
+		TotalChecks:            summary.TotalChecks,
+		UpChecks:               summary.UpChecks,
+		AchievedUptime:         summary.UptimePercent,
+		Met:                    summary.UptimePercent >= target,
+		AllowedDowntimeSeconds: int64(allowed.Seconds()),
+		DowntimeSeconds:        int64(downtime.Seconds()),
+		RemainingBudgetSeconds: int64((allowed - downtime).Seconds()),
+	}
+	if allowed > 0 {
+		response.BudgetConsumedPercent = roundTo2(float64(downtime) / float64(allowed) * 100)
+	}
+	if allowedRate > 0 {
+		response.BurnRate = roundTo2(failureRate / allowedRate)
+	}
+	return response
+}
+
+// normalizeSLAThresholds sorts and dedupes alert thresholds, falling back to the defaults.
+func normalizeSLAThresholds(thresholds []int64) models.Int64Array {
+	if len(thresholds) == 0 {
+		return append(models.Int64Array{}, DefaultSLAAlertThresholds...)
+	}
+
+	seen := map[int64]bool{}
+	normalized := models.Int64Array{}
+	for _, threshold := range thresholds {
+		if !seen[threshold] {
+			seen[threshold] = true
+			normalized = append(normalized, threshold)
+		}
+	}
+	sort.Slice(normalized, func(i, j int) bool { return normalized[i] < normalized[j] })
+	return normalized
+}
+
+func roundTo2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,49 @@
+package url
+
+import (
+	"context"
+	"uptimatic/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SLAAlertRepository interface {
+	ListThresholdsByURLID(ctx context.Context, tx *gorm.DB, urlID uint) ([]int64, error)
+	Create(ctx context.Context, tx *gorm.DB, urlID uint, thresholds []int64) error
+	Delete(ctx context.Context, tx *gorm.DB, urlID uint, thresholds []int64) error
+}
+
+type slaAlertRepository struct{}
+
+func NewSLAAlertRepository() SLAAlertRepository {
+	return &slaAlertRepository{}
+}
+
+func (r *slaAlertRepository) ListThresholdsByURLID(ctx context.Context, tx *gorm.DB, urlID uint) ([]int64, error) {
+	var thresholds []int64
+	err := tx.WithContext(ctx).Model(&models.SLAAlert{}).Where("url_id = ?", urlID).Pluck("threshold", &thresholds).Error
+	if err != nil {
+		return nil, err
+	}
+	return thresholds, nil
+}
+
+func (r *slaAlertRepository) Create(ctx context.Context, tx *gorm.DB, urlID uint, thresholds []int64) error {
+	if len(thresholds) == 0 {
+		return nil
+	}
+
+	alerts := make([]models.SLAAlert, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		alerts = append(alerts, models.SLAAlert{URLID: urlID, Threshold: threshold})
+	}
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&alerts).Error
+}
+
+func (r *slaAlertRepository) Delete(ctx context.Context, tx *gorm.DB, urlID uint, thresholds []int64) error {
+	if len(thresholds) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).Where("url_id = ? AND threshold IN ?", urlID, thresholds).Delete(&models.SLAAlert{}).Error
+}
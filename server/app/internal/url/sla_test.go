@@ -0,0 +1,97 @@
+package url
+
+import (
+	"testing"
+	"time"
+	"uptimatic/internal/models"
+)
+
+func TestNewSLAResponse(t *testing.T) {
+	const day = 24 * time.Hour
+	march := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	april := march.AddDate(0, 1, 0)
+	longAgo := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		target    float64
+		uptime    float64
+		createdAt time.Time
+		start     time.Time
+		end       time.Time
+		now       time.Time
+		want      SLAResponse
+	}{
+		{
+			name:      "budget overrun",
+			target:    99,
+			uptime:    98,
+			createdAt: longAgo,
+			start:     march,
+			end:       march.Add(30 * day),
+			now:       march.Add(30 * day),
+			want:      SLAResponse{AllowedDowntimeSeconds: 25920, DowntimeSeconds: 51840, RemainingBudgetSeconds: -25920, BudgetConsumedPercent: 200, BurnRate: 2},
+		},
+		{
+			name:      "calendar month starts with its full budget",
+			target:    99.5,
+			uptime:    100,
+			createdAt: longAgo,
+			start:     march,
+			end:       april,
+			now:       march.Add(15 * day),
+			want:      SLAResponse{Met: true, AllowedDowntimeSeconds: 13392, RemainingBudgetSeconds: 13392},
+		},
+		{
+			name:      "downtime measured from creation",
+			target:    99,
+			uptime:    99,
+			createdAt: march.Add(20 * day),
+			start:     march,
+			end:       march.Add(30 * day),
+			now:       march.Add(30 * day),
+			want:      SLAResponse{Met: true, AllowedDowntimeSeconds: 25920, DowntimeSeconds: 8640, RemainingBudgetSeconds: 17280, BudgetConsumedPercent: 33.33, BurnRate: 1},
+		},
+		{
+			name:      "created after now",
+			target:    99,
+			uptime:    0,
+			createdAt: march.Add(2 * day),
+			start:     march,
+			end:       march.Add(30 * day),
+			now:       march.Add(day),
+			want:      SLAResponse{AllowedDowntimeSeconds: 25920, RemainingBudgetSeconds: 25920, BurnRate: 100},
+		},
+		{
+			name:      "perfect target has no budget",
+			target:    100,
+			uptime:    99.99,
+			createdAt: longAgo,
+			start:     march,
+			end:       march.Add(30 * day),
+			now:       march.Add(day),
+			want:      SLAResponse{DowntimeSeconds: 8, RemainingBudgetSeconds: -8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.target
+			url := &models.URL{SLATarget: &target, SLAWindow: models.SLAWindowCalendarMonth, CreatedAt: tt.createdAt}
+			summary := &models.UptimeSummary{TotalChecks: 100, UpChecks: 99, UptimePercent: tt.uptime}
+
+			want := tt.want
+			want.Target = tt.target
+			want.Window = models.SLAWindowCalendarMonth
+			want.WindowStart = tt.start
+			want.WindowEnd = tt.end
+			want.TotalChecks = 100
+			want.UpChecks = 99
+			want.AchievedUptime = tt.uptime
+
+			if got := NewSLAResponse(url, summary, tt.start, tt.end, tt.now); got != want {
+				t.Errorf("NewSLAResponse() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
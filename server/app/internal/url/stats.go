@@ -0,0 +1,248 @@
+package url
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"uptimatic/internal/models"
+)
+
+// StatusNoResponse is the status recorded for checks that failed before any response arrived.
+const StatusNoResponse = 0
+
+// Error kinds of failed checks. The first group comes from the response status, the second from
+// checks that never got a response.
+const (
+	ErrorKindRedirect    = "redirect"
+	ErrorKindClientError = "client_error"
+	ErrorKindServerError = "server_error"
+	ErrorKindDNS         = "dns"
+	ErrorKindConnect     = "connect"
+	ErrorKindTLS         = "tls"
+	ErrorKindTimeout     = "timeout"
+	ErrorKindOther       = "other"
+)
+
+// errorKinds lists every error kind in the order they are reported.
+var errorKinds = []string{
+	ErrorKindRedirect, ErrorKindClientError, ErrorKindServerError,
+	ErrorKindDNS, ErrorKindConnect, ErrorKindTLS, ErrorKindTimeout, ErrorKindOther,
+}
+
+// errorKindConditions backs the error_kind log filter.
+var errorKindConditions = func() map[string]string {
+	conditions := map[string]string{}
+	for _, kind := range errorKinds {
+		conditions[kind] = fmt.Sprintf("error_kind = '%s'", kind)
+	}
+	return conditions
+}()
+
+// errorKindCountsSQL formats a jsonb object of failed check counts per error kind from a template
+// taking the kind.
+func errorKindCountsSQL(template string) string {
+	pairs := make([]string, 0, len(errorKinds))
+	for _, kind := range errorKinds {
+		pairs = append(pairs, fmt.Sprintf("'%s', %s", kind, fmt.Sprintf(template, kind)))
+	}
+	return "jsonb_build_object(" + strings.Join(pairs, ", ") + ")"
+}
+
+var statusClassConditions = map[string]string{
+	"1xx": "status BETWEEN 100 AND 199",
+	"2xx": "status BETWEEN 200 AND 299",
+	"3xx": "status BETWEEN 300 AND 399",
+	"4xx": "status BETWEEN 400 AND 499",
+	"5xx": "status BETWEEN 500 AND 599",
+}
+
+// ErrorKind returns the error kind of a response status, or an empty string for successful checks.
+func ErrorKind(status int) string {
+	switch {
+	case status >= 200 && status <= 299:
+		return ""
+	case status >= 300 && status <= 399:
+		return ErrorKindRedirect
+	case status >= 400 && status <= 499:
+		return ErrorKindClientError
+	case status >= 500:
+		return ErrorKindServerError
+	default:
+		return ErrorKindOther
+	}
+}
+
+// LogErrorKind returns the error kind recorded with a check, or an empty string for successful checks.
+func LogErrorKind(log *models.StatusLog) string {
+	if log.ErrorKind != nil {
+		return *log.ErrorKind
+	}
+	status, _ := strconv.Atoi(log.Status)
+	return ErrorKind(status)
+}
+
+// IsDown reports whether a check status counts as the monitor being down.
+func IsDown(status int) bool {
+	return status == StatusNoResponse || status >= 400
+}
+
+// statFields lists the optional aggregates of an uptime stats bucket and the SQL that computes each one.
+var statFields = []struct {
+	Name string
+	SQL  string
+}{
+	{"min", "MIN(response_time) FILTER (WHERE NOT in_maintenance)::float8 AS min_response_time"},
+	{"avg", "ROUND(AVG(response_time) FILTER (WHERE NOT in_maintenance), 2)::float8 AS avg_response_time"},
+	{"p50", "percentile_cont(0.5) WITHIN GROUP (ORDER BY response_time) FILTER (WHERE NOT in_maintenance) AS p50_response_time"},
+	{"p90", "percentile_cont(0.9) WITHIN GROUP (ORDER BY response_time) FILTER (WHERE NOT in_maintenance) AS p90_response_time"},
+	{"p95", "percentile_cont(0.95) WITHIN GROUP (ORDER BY response_time) FILTER (WHERE NOT in_maintenance) AS p95_response_time"},
+	{"p99", "percentile_cont(0.99) WITHIN GROUP (ORDER BY response_time) FILTER (WHERE NOT in_maintenance) AS p99_response_time"},
+	{"max", "MAX(response_time) FILTER (WHERE NOT in_maintenance)::float8 AS max_response_time"},
+	{"error_kinds", errorKindCountsSQL("COUNT(*) FILTER (WHERE error_kind = '%s' AND NOT in_maintenance)") + " AS error_kinds"},
+	{"timings", timingAveragesSQL("ROUND(AVG(%[1]s_time) FILTER (WHERE NOT in_maintenance), 2)::float8 AS avg_%[1]s_time")},
+}
+
+// timingPhases are the check phases with a timing column, in the order they stack up.
+var timingPhases = []string{"dns", "connect", "tls", "ttfb", "transfer"}
+
+// timingAveragesSQL formats one average per phase from a template taking the phase name.
+func timingAveragesSQL(template string) string {
+	averages := make([]string, 0, len(timingPhases))
+	for _, phase := range timingPhases {
+		averages = append(averages, fmt.Sprintf(template, phase))
+	}
+	return strings.Join(averages, ",\n\t\t\t")
+}
+
+// statFieldGroups are shorthands accepted by the fields flag.
+var statFieldGroups = map[string][]string{
+	"response_time": {"min", "avg", "p50", "p90", "p95", "p99", "max"},
+	"percentiles":   {"p50", "p90", "p95", "p99"},
+}
+
+// ParseStatFields turns the comma separated fields flag into the set of optional aggregates to compute.
+// An empty flag selects every aggregate.
+func ParseStatFields(flag string) (map[string]bool, error) {
+	fields := map[string]bool{}
+	if strings.TrimSpace(flag) == "" {
+		for _, field := range statFields {
+			fields[field.Name] = true
+		}
+		return fields, nil
+	}
+
+	known := map[string]bool{}
+	for _, field := range statFields {
+		known[field.Name] = true
+	}
+
+	for _, name := range strings.Split(flag, ",") {
+		name = strings.TrimSpace(name)
+		if group, ok := statFieldGroups[name]; ok {
+			for _, field := range group {
+				fields[field] = true
+			}
+			continue
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		fields[name] = true
+	}
+	return fields, nil
+}
+
+func statFieldsSQL(fields map[string]bool) string {
+	var b strings.Builder
+	for _, field := range statFields {
+		if fields[field.Name] {
+			b.WriteString(",\n\t\t\t")
+			b.WriteString(field.SQL)
+		}
+	}
+	return b.String()
+}
+
+const maxStatPoints = 1000
+
+// statBuckets maps the bucket flag to either a calendar unit truncated in the caller's time zone
+// or a fixed width for sub-daily buckets, which are binned on absolute time so DST never merges or splits them.
+var statBuckets = map[string]struct {
+	Unit  string
+	Width time.Duration
+}{
+	"1m": {Width: time.Minute},
+	"5m": {Width: 5 * time.Minute},
+	"1h": {Width: time.Hour},
+	"1d": {Unit: "day"},
+	"1w": {Unit: "week"},
+	"1M": {Unit: "month"},
+}
+
+var modeBuckets = map[string]string{
+	"day":   "1h",
+	"month": "1d",
+	"year":  "1M",
+}
+
+// rollupBucket reports whether a bucket is coarse enough to be served from the hourly or daily rollups.
+func rollupBucket(bucket string) bool {
+	b := statBuckets[bucket]
+	return b.Unit != "" || b.Width >= time.Hour
+}
+
+func isMidnight(t time.Time, loc *time.Location) bool {
+	local := t.In(loc)
+	return local.Hour() == 0 && local.Minute() == 0 && local.Second() == 0 && local.Nanosecond() == 0
+}
+
+func bucketSQL(bucket, column string) string {
+	b := statBuckets[bucket]
+	if b.Unit == "" {
+		seconds := int(b.Width.Seconds())
+		return fmt.Sprintf("to_timestamp(floor(extract(epoch FROM %s) / %d) * %d)", column, seconds, seconds)
+	}
+	return fmt.Sprintf("date_trunc('%s', %s, @tz)", b.Unit, column)
+}
+
+// bucketStarts lists every bucket start in [start, end) the same way bucketSQL groups rows,
+// and fails once more than maxStatPoints buckets would be produced.
+func bucketStarts(bucket string, start, end time.Time, loc *time.Location) ([]time.Time, error) {
+	b := statBuckets[bucket]
+
+	var current time.Time
+	if b.Unit == "" {
+		current = start.Truncate(b.Width)
+	} else {
+		local := start.In(loc)
+		switch b.Unit {
+		case "day":
+			current = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		case "week":
+			current = time.Date(local.Year(), local.Month(), local.Day()-(int(local.Weekday())+6)%7, 0, 0, 0, 0, loc)
+		case "month":
+			current = time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+		}
+	}
+
+	starts := []time.Time{}
+	for current.Before(end) {
+		if len(starts) == maxStatPoints {
+			return nil, fmt.Errorf("range produces more than %d buckets", maxStatPoints)
+		}
+		starts = append(starts, current)
+
+		switch b.Unit {
+		case "":
+			current = current.Add(b.Width)
+		case "day":
+			current = current.AddDate(0, 0, 1)
+		case "week":
+			current = current.AddDate(0, 0, 7)
+		case "month":
+			current = current.AddDate(0, 1, 0)
+		}
+	}
+	return starts, nil
+}
@@ -0,0 +1,94 @@
+package url
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketStarts(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+	local := func(y int, m time.Month, d, h int) time.Time { return time.Date(y, m, d, h, 0, 0, 0, ny) }
+
+	tests := []struct {
+		name    string
+		bucket  string
+		start   time.Time
+		end     time.Time
+		want    []time.Time
+		wantErr bool
+	}{
+		{
+			// 2026-03-08 is 23 hours long in New York, so the days are not 24h apart.
+			name:   "days across spring forward",
+			bucket: "1d",
+			start:  local(2026, 3, 7, 5),
+			end:    local(2026, 3, 10, 0),
+			want:   []time.Time{local(2026, 3, 7, 0), local(2026, 3, 8, 0), local(2026, 3, 9, 0)},
+		},
+		{
+			name:   "days across fall back",
+			bucket: "1d",
+			start:  local(2026, 10, 31, 0),
+			end:    local(2026, 11, 2, 12),
+			want:   []time.Time{local(2026, 10, 31, 0), local(2026, 11, 1, 0), local(2026, 11, 2, 0)},
+		},
+		{
+			name:   "hours across spring forward",
+			bucket: "1h",
+			start:  local(2026, 3, 8, 1),
+			end:    local(2026, 3, 8, 4),
+			want:   []time.Time{local(2026, 3, 8, 1), local(2026, 3, 8, 3)},
+		},
+		{
+			name:   "weeks start on monday",
+			bucket: "1w",
+			start:  local(2026, 3, 4, 10),
+			end:    local(2026, 3, 17, 0),
+			want:   []time.Time{local(2026, 3, 2, 0), local(2026, 3, 9, 0), local(2026, 3, 16, 0)},
+		},
+		{
+			name:   "months",
+			bucket: "1M",
+			start:  local(2026, 2, 15, 0),
+			end:    local(2026, 4, 1, 0),
+			want:   []time.Time{local(2026, 2, 1, 0), local(2026, 3, 1, 0)},
+		},
+		{
+			name:   "empty range",
+			bucket: "5m",
+			start:  local(2026, 3, 1, 0),
+			end:    local(2026, 3, 1, 0),
+			want:   []time.Time{},
+		},
+		{
+			name:    "too many buckets",
+			bucket:  "1m",
+			start:   local(2026, 3, 1, 0),
+			end:     local(2026, 3, 2, 0),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bucketStarts(tt.bucket, tt.start, tt.end, ny)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("bucketStarts() returned %d buckets, want error", len(got))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("bucketStarts() failed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("bucketStarts() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("bucketStarts()[%d] = %v, want %v", i, got[i].In(ny), tt.want[i])
+				}
+			}
+		})
+	}
+}
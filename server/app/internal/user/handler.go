@@ -13,6 +13,9 @@ import (
 type UserHandler interface {
 	UpdateUserHandler(c *gin.Context)
 	GetUserHandler(c *gin.Context)
+	UpdateReportPreferencesHandler(c *gin.Context)
+	CreateMetricsTokenHandler(c *gin.Context)
+	DeleteMetricsTokenHandler(c *gin.Context)
 	ChangePasswordHandler(c *gin.Context)
 	GetPresignedUrlHandler(c *gin.Context)
 	UpdateFotoHandler(c *gin.Context)
@@ -46,7 +49,7 @@ func (h *userHandler) UpdateUserHandler(c *gin.Context) {
 		return
 	}
 
-	user, changed, errSvc := h.userService.Update(c.Request.Context(), c.GetUint("user_id"), req.Name, req.Email, fmt.Sprintf("%s://%s", h.cfg.AppScheme, h.cfg.AppDomain), refreshToken)
+	user, changed, errSvc := h.userService.Update(c.Request.Context(), c.GetUint("user_id"), req.Name, req.Email, req.Timezone, fmt.Sprintf("%s://%s", h.cfg.AppScheme, h.cfg.AppDomain), refreshToken)
 	if errSvc != nil {
 		utils.ErrorResponse(c, errSvc)
 		return
@@ -69,6 +72,42 @@ func (h *userHandler) GetUserHandler(c *gin.Context) {
 	utils.SuccessResponse(c, user)
 }
 
+func (h *userHandler) UpdateReportPreferencesHandler(c *gin.Context) {
+	var req ReportPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, "Invalid JSON payload", err))
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		utils.BindErrorResponse(c, utils.NewAppError(http.StatusBadRequest, utils.ValidationError, err.Error(), err))
+		return
+	}
+
+	user, err := h.userService.UpdateReportPreferences(c.Request.Context(), c.GetUint("user_id"), req.Schedule, req.Timezone)
+	if err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, user)
+}
+
+func (h *userHandler) CreateMetricsTokenHandler(c *gin.Context) {
+	token, err := h.userService.CreateMetricsToken(c.Request.Context(), c.GetUint("user_id"))
+	if err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, MetricsTokenResponse{Token: token})
+}
+
+func (h *userHandler) DeleteMetricsTokenHandler(c *gin.Context) {
+	if err := h.userService.DeleteMetricsToken(c.Request.Context(), c.GetUint("user_id")); err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, nil)
+}
+
 func (h *userHandler) ChangePasswordHandler(c *gin.Context) {
 	var req ChangePasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
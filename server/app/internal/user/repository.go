@@ -12,6 +12,8 @@ type UserRepository interface {
 	Update(ctx context.Context, tx *gorm.DB, user *models.User) error
 	FindByID(ctx context.Context, tx *gorm.DB, id uint) (*models.User, error)
 	FindByEmail(ctx context.Context, tx *gorm.DB, email string) (*models.User, error)
+	ListReportSubscribers(ctx context.Context, tx *gorm.DB) ([]models.User, error)
+	FindByMetricsTokenHash(ctx context.Context, tx *gorm.DB, hash string) (*models.User, error)
 }
 
 type userRepository struct{}
@@ -45,3 +47,22 @@ func (r *userRepository) FindByEmail(ctx context.Context, tx *gorm.DB, email str
 	}
 	return &user, nil
 }
+
+// ListReportSubscribers returns the verified users that opted in to scheduled report emails.
+func (r *userRepository) ListReportSubscribers(ctx context.Context, tx *gorm.DB) ([]models.User, error) {
+	var users []models.User
+	err := tx.WithContext(ctx).Where("verified = ? AND report_schedule <> ?", true, models.ReportScheduleNone).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *userRepository) FindByMetricsTokenHash(ctx context.Context, tx *gorm.DB, hash string) (*models.User, error) {
+	var user models.User
+	err := tx.WithContext(ctx).Where("metrics_token_hash = ?", hash).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
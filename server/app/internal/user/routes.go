@@ -15,6 +15,9 @@ func UserRoutes(r *gin.RouterGroup, h UserHandler, jwtUtil *utils.JWTUtil) {
 	{
 		users.PUT("", h.UpdateUserHandler)
 		users.PUT("/change-password", h.ChangePasswordHandler)
+		users.PUT("/report-preferences", h.UpdateReportPreferencesHandler)
+		users.POST("/metrics-token", h.CreateMetricsTokenHandler)
+		users.DELETE("/metrics-token", h.DeleteMetricsTokenHandler)
 		users.POST("/upload-url", h.GetPresignedUrlHandler)
 		users.PUT("/update-foto", h.UpdateFotoHandler)
 	}
@@ -1,8 +1,20 @@
 package user
 
 type UpdateUserRequest struct {
-	Name  string `json:"name" validate:"required"`
-	Email string `json:"email" validate:"email,required"`
+	Name     string `json:"name" validate:"required"`
+	Email    string `json:"email" validate:"email,required"`
+	Timezone string `json:"timezone"`
+}
+
+// ReportPreferencesRequest sets the scheduled uptime report email; reports are timed and cut in Timezone.
+type ReportPreferencesRequest struct {
+	Schedule string `json:"schedule" validate:"required,oneof=none weekly monthly"`
+	Timezone string `json:"timezone"`
+}
+
+// MetricsTokenResponse carries a new exporter token; it cannot be retrieved again later.
+type MetricsTokenResponse struct {
+	Token string `json:"token"`
 }
 
 type ChangePasswordRequest struct {
@@ -2,6 +2,9 @@ package user
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -20,8 +23,11 @@ import (
 )
 
 type UserService interface {
-	Update(ctx context.Context, userId uint, name, userEmail, appUrl, oldRefresh string) (*models.User, map[string]any, *utils.AppError)
+	Update(ctx context.Context, userId uint, name, userEmail, timezone, appUrl, oldRefresh string) (*models.User, map[string]any, *utils.AppError)
 	GetUser(ctx context.Context, userId uint) (*models.User, *utils.AppError)
+	UpdateReportPreferences(ctx context.Context, userId uint, schedule, timezone string) (*models.User, *utils.AppError)
+	CreateMetricsToken(ctx context.Context, userId uint) (string, *utils.AppError)
+	DeleteMetricsToken(ctx context.Context, userId uint) *utils.AppError
 	ChangePassword(ctx context.Context, userId uint, oldPassword, newPassword string) *utils.AppError
 	GetPresignedUrl(ctx context.Context, fileName string, contentType string) (string, string, *utils.AppError)
 	UpdateFoto(ctx context.Context, userId uint, fileName string) (string, *utils.AppError)
@@ -40,7 +46,7 @@ func NewUserService(db *gorm.DB, userRepo UserRepository, minio *minio.MinioUtil
 	return &userService{db, userRepo, minio, redis, jwtUtil, asyncClient}
 }
 
-func (s *userService) Update(ctx context.Context, userId uint, name, userEmail, appUrl, oldRefresh string) (*models.User, map[string]any, *utils.AppError) {
+func (s *userService) Update(ctx context.Context, userId uint, name, userEmail, timezone, appUrl, oldRefresh string) (*models.User, map[string]any, *utils.AppError) {
 	utils.Info(ctx, "Updating user profile", map[string]any{"name": name, "email": userEmail})
 
 	user, err := s.userRepo.FindByID(ctx, s.db, userId)
@@ -49,6 +55,16 @@ func (s *userService) Update(ctx context.Context, userId uint, name, userEmail,
 		return nil, nil, utils.InternalServerError("Error finding user", err)
 	}
 
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			utils.Warn(ctx, "Invalid timezone", map[string]any{"user_id": userId, "timezone": timezone})
+			return nil, nil, utils.ValidationErrorErr(map[string][]map[string]any{
+				"timezone": {{"code": utils.InvalidFormat}},
+			})
+		}
+		user.Timezone = timezone
+	}
+
 	if userEmail == user.Email {
 		user.Name = name
 		if err := s.userRepo.Update(ctx, s.db, user); err != nil {
@@ -76,7 +92,7 @@ func (s *userService) Update(ctx context.Context, userId uint, name, userEmail,
 
 	link := fmt.Sprintf("%s/auth/verify?token=%s", appUrl, token)
 	iconUrl := fmt.Sprintf("%s/icon.png", appUrl)
-	if err := tasks.EnqueueEmail(s.asyncClient, user.Email, "Verify your email - Uptimatic", email.EmailVerify, map[string]any{
+	if err := tasks.EnqueueEmail(ctx, s.asyncClient, user.Email, "Verify your email - Uptimatic", email.EmailVerify, map[string]any{
 		"LogoURL":          iconUrl,
 		"Name":             user.Email,
 		"VerificationLink": link,
@@ -144,6 +160,84 @@ func (s *userService) GetUser(ctx context.Context, userId uint) (*models.User, *
 	return user, nil
 }
 
+func (s *userService) UpdateReportPreferences(ctx context.Context, userId uint, schedule, timezone string) (*models.User, *utils.AppError) {
+	utils.Info(ctx, "Updating report preferences", map[string]any{"user_id": userId, "schedule": schedule})
+
+	user, err := s.userRepo.FindByID(ctx, s.db, userId)
+	if err != nil {
+		utils.Error(ctx, "Error retrieving user profile", map[string]any{"user_id": userId, "err": err.Error()})
+		return nil, utils.InternalServerError("Error finding user", err)
+	}
+
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			utils.Warn(ctx, "Invalid timezone", map[string]any{"user_id": userId, "timezone": timezone})
+			return nil, utils.ValidationErrorErr(map[string][]map[string]any{
+				"timezone": {{"code": utils.InvalidFormat}},
+			})
+		}
+		user.Timezone = timezone
+	}
+	user.ReportSchedule = schedule
+
+	if err := s.userRepo.Update(ctx, s.db, user); err != nil {
+		utils.Error(ctx, "Error updating report preferences", map[string]any{"user_id": userId, "err": err.Error()})
+		return nil, utils.InternalServerError("Error updating user", err)
+	}
+
+	if user.Profile != "" {
+		url := s.minio.GetPublicURL(ctx, user.Profile)
+		user.Profile = url
+	}
+
+	utils.Info(ctx, "Report preferences updated successfully", map[string]any{"user_id": userId})
+	return user, nil
+}
+
+// CreateMetricsToken issues the token that authenticates scrapes of the monitor exporter, replacing
+// any previous one. Only its hash is stored, so the token is shown once.
+func (s *userService) CreateMetricsToken(ctx context.Context, userId uint) (string, *utils.AppError) {
+	user, err := s.userRepo.FindByID(ctx, s.db, userId)
+	if err != nil {
+		utils.Error(ctx, "Error retrieving user profile", map[string]any{"user_id": userId, "err": err.Error()})
+		return "", utils.InternalServerError("Error finding user", err)
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		utils.Error(ctx, "Error generating metrics token", map[string]any{"user_id": userId, "err": err.Error()})
+		return "", utils.InternalServerError("Error generating token", err)
+	}
+	token := metricsTokenPrefix + hex.EncodeToString(buf)
+	hash := HashMetricsToken(token)
+	user.MetricsTokenHash = &hash
+
+	if err := s.userRepo.Update(ctx, s.db, user); err != nil {
+		utils.Error(ctx, "Error saving metrics token", map[string]any{"user_id": userId, "err": err.Error()})
+		return "", utils.InternalServerError("Error updating user", err)
+	}
+
+	utils.Info(ctx, "Metrics token created", map[string]any{"user_id": userId})
+	return token, nil
+}
+
+func (s *userService) DeleteMetricsToken(ctx context.Context, userId uint) *utils.AppError {
+	user, err := s.userRepo.FindByID(ctx, s.db, userId)
+	if err != nil {
+		utils.Error(ctx, "Error retrieving user profile", map[string]any{"user_id": userId, "err": err.Error()})
+		return utils.InternalServerError("Error finding user", err)
+	}
+
+	user.MetricsTokenHash = nil
+	if err := s.userRepo.Update(ctx, s.db, user); err != nil {
+		utils.Error(ctx, "Error revoking metrics token", map[string]any{"user_id": userId, "err": err.Error()})
+		return utils.InternalServerError("Error updating user", err)
+	}
+
+	utils.Info(ctx, "Metrics token revoked", map[string]any{"user_id": userId})
+	return nil
+}
+
 func (s *userService) ChangePassword(ctx context.Context, userId uint, oldPassword, newPassword string) *utils.AppError {
 	utils.Info(ctx, "Changing user password", map[string]any{"user_id": userId})
 
@@ -203,3 +297,12 @@ func (s *userService) UpdateFoto(ctx context.Context, userId uint, fileName stri
 	utils.Info(ctx, "User photo updated successfully", map[string]any{"user_id": userId})
 	return url, nil
 }
+
+// metricsTokenPrefix makes exporter tokens recognisable in configs and secret scanners.
+const metricsTokenPrefix = "upt_"
+
+// HashMetricsToken is how exporter tokens are stored and looked up.
+func HashMetricsToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
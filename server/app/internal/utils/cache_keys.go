@@ -15,3 +15,15 @@ func GetEmailVerificationTokenKey(email string) string {
 func GetPasswordResetTokenKey(email string) string {
 	return fmt.Sprintf("password_reset_token:%s", email)
 }
+
+func GetBadgeKey(urlID, kind string) string {
+	return fmt.Sprintf("badge:%s:%s", urlID, kind)
+}
+
+func GetMonitorCheckKey(urlID uint) string {
+	return fmt.Sprintf("monitor_metrics:%d:check", urlID)
+}
+
+func GetMonitorSLAKey(urlID uint) string {
+	return fmt.Sprintf("monitor_metrics:%d:sla", urlID)
+}
@@ -10,3 +10,5 @@ func ContainsInt(target int) bool {
 	}
 	return false
 }
+
+const DefaultTimezone = "Asia/Jakarta"
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// EncodeCursor builds an opaque keyset cursor from the sort timestamp and id of the last returned row.
+func EncodeCursor(t time.Time, id uint) string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%d", t.UnixNano(), id))
+}
+
+func DecodeCursor(cursor string) (time.Time, uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	var nanos int64
+	var id uint
+	if _, err := fmt.Sscanf(string(raw), "%d:%d", &nanos, &id); err != nil {
+		return time.Time{}, 0, err
+	}
+	return time.Unix(0, nanos).UTC(), id, nil
+}
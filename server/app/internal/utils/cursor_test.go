@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		id   uint
+	}{
+		{name: "utc", t: time.Date(2026, 3, 8, 7, 30, 0, 0, time.UTC), id: 42},
+		{name: "nanoseconds", t: time.Date(2026, 3, 8, 7, 30, 0, 123456789, time.UTC), id: 1},
+		{name: "other zone", t: time.Date(2026, 3, 8, 7, 30, 0, 0, time.FixedZone("WIB", 7*60*60)), id: 7},
+		{name: "before epoch", t: time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC), id: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTime, gotID, err := DecodeCursor(EncodeCursor(tt.t, tt.id))
+			if err != nil {
+				t.Fatalf("DecodeCursor() failed: %v", err)
+			}
+			if !gotTime.Equal(tt.t) || gotTime.Location() != time.UTC {
+				t.Errorf("DecodeCursor() time = %v, want %v in UTC", gotTime, tt.t)
+			}
+			if gotID != tt.id {
+				t.Errorf("DecodeCursor() id = %d, want %d", gotID, tt.id)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "empty", cursor: ""},
+		{name: "not base64", cursor: "!!!"},
+		{name: "padded base64", cursor: base64.URLEncoding.EncodeToString([]byte("1:23"))},
+		{name: "missing id", cursor: encode("1700000000")},
+		{name: "not a number", cursor: encode("abc:1")},
+		{name: "negative id", cursor: encode("1700000000:-1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := DecodeCursor(tt.cursor); err == nil {
+				t.Errorf("DecodeCursor(%q) succeeded, want error", tt.cursor)
+			}
+		})
+	}
+}
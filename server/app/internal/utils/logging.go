@@ -5,16 +5,44 @@ import (
 	"fmt"
 	"os"
 	"time"
+	"uptimatic/internal/config"
 
 	"github.com/getsentry/sentry-go"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var Logger zerolog.Logger
 
-func InitLogger(levelStr string) {
+// Version is the build version stamped on every log line, set at build time with
+// -ldflags "-X uptimatic/internal/utils.Version=<version>".
+var Version = "dev"
+
+const (
+	LogFormatConsole = "console"
+	LogFormatJSON    = "json"
+)
+
+// reservedLogFields are set by the logger itself; extra fields with these names are prefixed so
+// they cannot overwrite them.
+var reservedLogFields = map[string]bool{
+	zerolog.LevelFieldName:     true,
+	zerolog.TimestampFieldName: true,
+	zerolog.MessageFieldName:   true,
+	string(TraceKey):           true,
+	"span_id":                  true,
+	"request_id":               true,
+	"service":                  true,
+	"version":                  true,
+	"hostname":                 true,
+}
+
+// InitLogger sets up the global logger for one command. Console output is meant for people and
+// JSON output for log shippers; the optional file sink always gets JSON and rotates by size.
+func InitLogger(cfg *config.Config, service string) {
 	var level zerolog.Level
-	switch levelStr {
+	switch cfg.AppLogLevel {
 	case "DEBUG":
 		level = zerolog.DebugLevel
 	case "INFO":
@@ -32,19 +60,44 @@ func InitLogger(levelStr string) {
 	zerolog.SetGlobalLevel(level)
 	zerolog.TimeFieldFormat = time.RFC3339Nano
 
-	stdoutWriter := zerolog.NewConsoleWriter(func(w *zerolog.ConsoleWriter) {
-		w.Out = os.Stdout
-		w.TimeFormat = time.RFC3339Nano
-	})
-	stderrWriter := zerolog.NewConsoleWriter(func(w *zerolog.ConsoleWriter) {
-		w.Out = os.Stderr
-		w.TimeFormat = time.RFC3339Nano
-	})
+	var writer zerolog.LevelWriter
+	if cfg.AppLogFormat == LogFormatJSON {
+		writer = levelSplitter{
+			stdout: zerolog.MultiLevelWriter(os.Stdout),
+			stderr: zerolog.MultiLevelWriter(os.Stderr),
+		}
+	} else {
+		stdoutWriter := zerolog.NewConsoleWriter(func(w *zerolog.ConsoleWriter) {
+			w.Out = os.Stdout
+			w.TimeFormat = time.RFC3339Nano
+		})
+		stderrWriter := zerolog.NewConsoleWriter(func(w *zerolog.ConsoleWriter) {
+			w.Out = os.Stderr
+			w.TimeFormat = time.RFC3339Nano
+		})
+		writer = levelSplitter{
+			stdout: writerAdapter{stdoutWriter},
+			stderr: writerAdapter{stderrWriter},
+		}
+	}
+
+	if cfg.AppLogFile != "" {
+		writer = zerolog.MultiLevelWriter(writer, &lumberjack.Logger{
+			Filename:   cfg.AppLogFile,
+			MaxSize:    cfg.AppLogFileMaxSize,
+			MaxBackups: cfg.AppLogFileMaxBackups,
+			MaxAge:     cfg.AppLogFileMaxAge,
+			Compress:   true,
+		})
+	}
 
-	Logger = zerolog.New(levelSplitter{
-		stdout: writerAdapter{stdoutWriter},
-		stderr: writerAdapter{stderrWriter},
-	}).With().Timestamp().Logger()
+	hostname, _ := os.Hostname()
+	Logger = zerolog.New(writer).With().
+		Timestamp().
+		Str("service", service).
+		Str("version", Version).
+		Str("hostname", hostname).
+		Logger()
 }
 
 func InitSentry(dsn string) error {
@@ -85,15 +138,29 @@ func (s levelSplitter) WriteLevel(level zerolog.Level, p []byte) (n int, err err
 	return s.stdout.WriteLevel(level, p)
 }
 
+// commonFields flattens extra into top-level fields, redacting secrets on the way, and adds the
+// trace and request IDs found in ctx.
 func commonFields(ctx context.Context, extra map[string]any) map[string]any {
-	fields := map[string]any{}
+	fields := make(map[string]any, len(extra)+3)
+	for key, value := range extra {
+		if reservedLogFields[key] {
+			key = "extra_" + key
+		}
+		fields[key] = redactField(key, value)
+	}
 	if ctx != nil {
-		if reqID, ok := ctx.Value(TraceKey).(string); ok {
+		reqID, hasReqID := ctx.Value(TraceKey).(string)
+		// Inside a span the OpenTelemetry trace ID wins, so a log line can be looked up in the
+		// tracing backend. The request ID is kept alongside since it is what clients see.
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			fields[string(TraceKey)] = span.TraceID().String()
+			fields["span_id"] = span.SpanID().String()
+		} else if hasReqID {
 			fields[string(TraceKey)] = reqID
 		}
-	}
-	if extra != nil {
-		fields["extra"] = extra
+		if hasReqID {
+			fields["request_id"] = reqID
+		}
 	}
 	return fields
 }
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are matched as substrings of lower-cased field names, so "metrics_token" and
+// "smtp_password" are caught along with the plain names.
+var sensitiveKeys = []string{"password", "passwd", "secret", "token", "authorization", "cookie", "api_key", "apikey", "credential", "dsn"}
+
+// sensitiveValues catch secrets embedded in otherwise harmless values such as error messages.
+var sensitiveValues = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)(bearer|basic)\s+[A-Za-z0-9\-._~+/]+=*`), "$1 " + redacted},
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`), redacted},
+	{regexp.MustCompile(`upt_[0-9a-f]{64}`), "upt_" + redacted},
+	{regexp.MustCompile(`://([^:/@\s]+):[^@\s]+@`), "://$1:" + redacted + "@"},
+}
+
+func sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactString(value string) string {
+	for _, sensitive := range sensitiveValues {
+		value = sensitive.pattern.ReplaceAllString(value, sensitive.replacement)
+	}
+	return value
+}
+
+// redactField hides the value of a sensitive field entirely and scrubs secrets out of strings,
+// errors and nested maps otherwise.
+func redactField(key string, value any) any {
+	if value == nil {
+		return nil
+	}
+	if sensitiveKey(key) {
+		return redacted
+	}
+
+	switch v := value.(type) {
+	case string:
+		return redactString(v)
+	case error:
+		return redactString(v.Error())
+	case map[string]any:
+		nested := make(map[string]any, len(v))
+		for k, item := range v {
+			nested[k] = redactField(k, item)
+		}
+		return nested
+	default:
+		return value
+	}
+}
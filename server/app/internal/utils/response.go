@@ -48,6 +48,13 @@ func SuccessResponse(c *gin.Context, data any) {
 	})
 }
 
+func AcceptedResponse(c *gin.Context, data any) {
+	c.JSON(http.StatusAccepted, gin.H{
+		"request_id": getRequestID(c.Request.Context()),
+		"data":       data,
+	})
+}
+
 func PaginatedResponse(c *gin.Context, data any, count, limit, page, totalPage int) {
 	c.JSON(http.StatusOK, gin.H{
 		"request_id": getRequestID(c.Request.Context()),
@@ -61,6 +68,18 @@ func PaginatedResponse(c *gin.Context, data any, count, limit, page, totalPage i
 	})
 }
 
+func CursorResponse(c *gin.Context, data any, limit int, nextCursor string) {
+	c.JSON(http.StatusOK, gin.H{
+		"request_id": getRequestID(c.Request.Context()),
+		"data":       data,
+		"meta": gin.H{
+			"limit":       limit,
+			"next_cursor": nextCursor,
+			"has_more":    nextCursor != "",
+		},
+	})
+}
+
 func ErrorResponse(c *gin.Context, appErr *AppError) {
 	resp := gin.H{
 		"request_id": getRequestID(c.Request.Context()),
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"uptimatic/cmd/restore"
 	"uptimatic/cmd/scheduler"
 	"uptimatic/cmd/server"
 	"uptimatic/cmd/worker"
@@ -10,7 +11,7 @@ import (
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: nama_app [server|worker|scheduler]")
+		fmt.Println("Usage: nama_app [server|worker|scheduler|restore]")
 		os.Exit(1)
 	}
 
@@ -21,6 +22,8 @@ func main() {
 		worker.Start()
 	case "scheduler":
 		scheduler.Start()
+	case "restore":
+		restore.Start(os.Args[2:])
 	default:
 		fmt.Println("Unknown command:", os.Args[1])
 	}
@@ -0,0 +1,5 @@
+ALTER TABLE status_logs
+DROP COLUMN IF EXISTS in_maintenance;
+
+DROP TABLE IF EXISTS maintenance_window_urls;
+DROP TABLE IF EXISTS maintenance_windows;
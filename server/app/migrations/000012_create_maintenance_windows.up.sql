@@ -0,0 +1,26 @@
+CREATE TABLE maintenance_windows (
+    id SERIAL PRIMARY KEY,
+    public_id uuid NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    starts_at timestamptz NOT NULL,
+    ends_at timestamptz,
+    recurrence_type VARCHAR(10) NOT NULL DEFAULT 'once',
+    recurrence TEXT,
+    duration_minutes INTEGER NOT NULL DEFAULT 0,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at timestamptz DEFAULT NOW()
+);
+
+CREATE TABLE maintenance_window_urls (
+    maintenance_window_id INT NOT NULL REFERENCES maintenance_windows(id) ON DELETE CASCADE,
+    url_id INT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    PRIMARY KEY (maintenance_window_id, url_id)
+);
+
+CREATE INDEX idx_maintenance_window_urls_url_id ON maintenance_window_urls(url_id);
+
+ALTER TABLE status_logs
+ADD COLUMN in_maintenance BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+ALTER TABLE status_logs
+DROP COLUMN IF EXISTS dependent;
+
+DROP TABLE IF EXISTS url_dependencies;
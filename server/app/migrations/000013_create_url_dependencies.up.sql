@@ -0,0 +1,11 @@
+CREATE TABLE url_dependencies (
+    url_id INT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    parent_id INT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    PRIMARY KEY (url_id, parent_id),
+    CHECK (url_id <> parent_id)
+);
+
+CREATE INDEX idx_url_dependencies_parent_id ON url_dependencies(parent_id);
+
+ALTER TABLE status_logs
+ADD COLUMN dependent BOOLEAN NOT NULL DEFAULT FALSE;
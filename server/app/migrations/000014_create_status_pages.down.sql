@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS status_page_components;
+DROP TABLE IF EXISTS status_pages;
+DROP TABLE IF EXISTS incidents;
@@ -0,0 +1,34 @@
+CREATE TABLE incidents (
+    id SERIAL PRIMARY KEY,
+    public_id uuid NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    url_id INT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    status_code INTEGER NOT NULL,
+    started_at timestamptz NOT NULL,
+    resolved_at timestamptz,
+    created_at timestamptz DEFAULT NOW()
+);
+
+CREATE INDEX idx_incidents_url_id_started_at ON incidents(url_id, started_at);
+
+CREATE TABLE status_pages (
+    id SERIAL PRIMARY KEY,
+    public_id uuid NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    logo TEXT,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+    password TEXT,
+    created_at timestamptz DEFAULT NOW()
+);
+
+CREATE TABLE status_page_components (
+    id SERIAL PRIMARY KEY,
+    public_id uuid NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    status_page_id INT NOT NULL REFERENCES status_pages(id) ON DELETE CASCADE,
+    url_id INT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (status_page_id, url_id)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS status_page_subscribers;
+DROP TABLE IF EXISTS status_page_incident_updates;
+DROP TABLE IF EXISTS status_page_incident_components;
+DROP TABLE IF EXISTS status_page_incidents;
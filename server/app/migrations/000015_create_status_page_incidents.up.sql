@@ -0,0 +1,40 @@
+CREATE TABLE status_page_incidents (
+    id SERIAL PRIMARY KEY,
+    public_id uuid NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    status_page_id INT NOT NULL REFERENCES status_pages(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    resolved_at timestamptz,
+    created_at timestamptz DEFAULT NOW(),
+    updated_at timestamptz DEFAULT NOW()
+);
+
+CREATE INDEX idx_status_page_incidents_status_page_id ON status_page_incidents(status_page_id, created_at);
+
+CREATE TABLE status_page_incident_components (
+    status_page_incident_id INT NOT NULL REFERENCES status_page_incidents(id) ON DELETE CASCADE,
+    status_page_component_id INT NOT NULL REFERENCES status_page_components(id) ON DELETE CASCADE,
+    PRIMARY KEY (status_page_incident_id, status_page_component_id)
+);
+
+CREATE TABLE status_page_incident_updates (
+    id SERIAL PRIMARY KEY,
+    public_id uuid NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    status_page_incident_id INT NOT NULL REFERENCES status_page_incidents(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    message TEXT NOT NULL,
+    created_at timestamptz DEFAULT NOW()
+);
+
+CREATE TABLE status_page_subscribers (
+    id SERIAL PRIMARY KEY,
+    public_id uuid NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    status_page_id INT NOT NULL REFERENCES status_pages(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    confirmed BOOLEAN NOT NULL DEFAULT FALSE,
+    confirm_token uuid NOT NULL UNIQUE,
+    unsubscribe_token uuid NOT NULL UNIQUE,
+    confirmed_at timestamptz,
+    created_at timestamptz DEFAULT NOW(),
+    UNIQUE (status_page_id, email)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE urls
+ADD COLUMN badge_visible boolean NOT NULL DEFAULT false;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_status_logs_checked_at;
+DROP TABLE IF EXISTS status_log_rollup_watermark;
+DROP TABLE IF EXISTS status_log_rollups_daily;
+DROP TABLE IF EXISTS status_log_rollups_hourly;
+DROP AGGREGATE IF EXISTS histogram_sum(BIGINT[]);
+DROP FUNCTION IF EXISTS histogram_add(BIGINT[], BIGINT[]);
@@ -0,0 +1,63 @@
+CREATE OR REPLACE FUNCTION histogram_add(a BIGINT[], b BIGINT[]) RETURNS BIGINT[] AS $$
+    SELECT CASE
+        WHEN a IS NULL THEN b
+        WHEN b IS NULL THEN a
+        ELSE ARRAY(
+            SELECT COALESCE(x, 0) + COALESCE(y, 0)
+            FROM unnest(a, b) WITH ORDINALITY AS t(x, y, i)
+            ORDER BY i
+        )
+    END
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE AGGREGATE histogram_sum(BIGINT[]) (
+    SFUNC = histogram_add,
+    STYPE = BIGINT[]
+);
+
+CREATE TABLE status_log_rollups_hourly (
+    url_id INT NOT NULL REFERENCES urls(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    bucket_start timestamptz NOT NULL,
+    total_checks INT NOT NULL,
+    up_checks INT NOT NULL,
+    maintenance_checks INT NOT NULL,
+    response_time_sum BIGINT NOT NULL,
+    response_time_count INT NOT NULL,
+    min_response_time INT,
+    max_response_time INT,
+    redirect_checks INT NOT NULL,
+    client_error_checks INT NOT NULL,
+    server_error_checks INT NOT NULL,
+    other_checks INT NOT NULL,
+    response_time_histogram BIGINT[] NOT NULL,
+    PRIMARY KEY (url_id, bucket_start)
+);
+
+CREATE INDEX idx_status_log_rollups_hourly_bucket_start ON status_log_rollups_hourly(bucket_start);
+
+CREATE TABLE status_log_rollups_daily (
+    url_id INT NOT NULL REFERENCES urls(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    bucket_start timestamptz NOT NULL,
+    total_checks INT NOT NULL,
+    up_checks INT NOT NULL,
+    maintenance_checks INT NOT NULL,
+    response_time_sum BIGINT NOT NULL,
+    response_time_count INT NOT NULL,
+    min_response_time INT,
+    max_response_time INT,
+    redirect_checks INT NOT NULL,
+    client_error_checks INT NOT NULL,
+    server_error_checks INT NOT NULL,
+    other_checks INT NOT NULL,
+    response_time_histogram BIGINT[] NOT NULL,
+    PRIMARY KEY (url_id, bucket_start)
+);
+
+CREATE TABLE status_log_rollup_watermark (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    rolled_up_to timestamptz
+);
+
+INSERT INTO status_log_rollup_watermark (id, rolled_up_to) VALUES (TRUE, NULL);
+
+CREATE INDEX IF NOT EXISTS idx_status_logs_checked_at ON status_logs(checked_at);
@@ -0,0 +1,24 @@
+ALTER TABLE status_logs RENAME TO status_logs_partitioned;
+ALTER TABLE status_logs_partitioned RENAME CONSTRAINT status_logs_pkey TO status_logs_partitioned_pkey;
+ALTER TABLE status_logs_partitioned RENAME CONSTRAINT status_logs_url_id_fkey TO status_logs_partitioned_url_id_fkey;
+ALTER INDEX idx_status_logs_checked_at RENAME TO idx_status_logs_partitioned_checked_at;
+
+CREATE TABLE status_logs (
+    id INT NOT NULL DEFAULT nextval('status_logs_id_seq') PRIMARY KEY,
+    url_id INT REFERENCES urls(id) ON DELETE CASCADE,
+    status INTEGER NOT NULL,
+    response_time INT,
+    checked_at timestamptz DEFAULT NOW(),
+    in_maintenance BOOLEAN NOT NULL DEFAULT FALSE,
+    dependent BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+ALTER SEQUENCE status_logs_id_seq OWNED BY status_logs.id;
+
+CREATE INDEX idx_status_logs_checked_at ON status_logs(checked_at);
+
+INSERT INTO status_logs (id, url_id, status, response_time, checked_at, in_maintenance, dependent)
+SELECT id, url_id, status, response_time, checked_at, in_maintenance, dependent
+FROM status_logs_partitioned;
+
+DROP TABLE status_logs_partitioned;
@@ -0,0 +1,50 @@
+ALTER TABLE status_logs RENAME TO status_logs_old;
+ALTER TABLE status_logs_old RENAME CONSTRAINT status_logs_pkey TO status_logs_old_pkey;
+ALTER TABLE status_logs_old RENAME CONSTRAINT status_logs_url_id_fkey TO status_logs_old_url_id_fkey;
+ALTER INDEX idx_status_logs_checked_at RENAME TO idx_status_logs_old_checked_at;
+
+CREATE TABLE status_logs (
+    id INT NOT NULL DEFAULT nextval('status_logs_id_seq'),
+    url_id INT REFERENCES urls(id) ON DELETE CASCADE,
+    status INTEGER NOT NULL,
+    response_time INT,
+    checked_at timestamptz NOT NULL DEFAULT NOW(),
+    in_maintenance BOOLEAN NOT NULL DEFAULT FALSE,
+    dependent BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (id, checked_at)
+) PARTITION BY RANGE (checked_at);
+
+ALTER SEQUENCE status_logs_id_seq OWNED BY status_logs.id;
+
+CREATE INDEX idx_status_logs_url_id_checked_at ON status_logs(url_id, checked_at);
+CREATE INDEX idx_status_logs_checked_at ON status_logs(checked_at);
+
+-- Monthly partitions named status_logs_YYYYMM cover existing data and the next three months;
+-- the partition maintenance task keeps creating them ahead of time from here on.
+DO $$
+DECLARE
+    month_start timestamp;
+    last_month timestamp;
+BEGIN
+    SELECT date_trunc('month', COALESCE(MIN(checked_at), NOW()) AT TIME ZONE 'UTC') INTO month_start FROM status_logs_old;
+    last_month := date_trunc('month', NOW() AT TIME ZONE 'UTC') + INTERVAL '3 months';
+
+    WHILE month_start <= last_month LOOP
+        EXECUTE format(
+            'CREATE TABLE %I PARTITION OF status_logs FOR VALUES FROM (%L) TO (%L)',
+            'status_logs_' || to_char(month_start, 'YYYYMM'),
+            month_start AT TIME ZONE 'UTC',
+            (month_start + INTERVAL '1 month') AT TIME ZONE 'UTC'
+        );
+        month_start := month_start + INTERVAL '1 month';
+    END LOOP;
+END $$;
+
+-- Catches rows outside every monthly partition so inserts never fail if maintenance falls behind.
+CREATE TABLE status_logs_default PARTITION OF status_logs DEFAULT;
+
+INSERT INTO status_logs (id, url_id, status, response_time, checked_at, in_maintenance, dependent)
+SELECT id, url_id, status, response_time, COALESCE(checked_at, NOW()), in_maintenance, dependent
+FROM status_logs_old;
+
+DROP TABLE status_logs_old;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS status_logs_restored;
+DROP TABLE IF EXISTS status_log_archives;
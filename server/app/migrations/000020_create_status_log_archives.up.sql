@@ -0,0 +1,29 @@
+-- One row per monitor and month of status logs written to object storage before its partition was
+-- dropped. The monitor columns are copied rather than referenced so archives outlive deleted monitors.
+CREATE TABLE status_log_archives (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    url_id INT NOT NULL,
+    url_public_id UUID NOT NULL,
+    month timestamptz NOT NULL,
+    object_key TEXT NOT NULL,
+    row_count BIGINT NOT NULL,
+    archived_at timestamptz NOT NULL DEFAULT NOW(),
+    UNIQUE (url_public_id, month)
+);
+
+-- Archived logs loaded back for analysis; kept apart from status_logs so retention and rollups
+-- never see them.
+CREATE TABLE status_logs_restored (
+    id INT NOT NULL,
+    url_id INT NOT NULL,
+    status INTEGER NOT NULL,
+    response_time INT,
+    checked_at timestamptz NOT NULL,
+    in_maintenance BOOLEAN NOT NULL DEFAULT FALSE,
+    dependent BOOLEAN NOT NULL DEFAULT FALSE,
+    restored_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, checked_at)
+);
+
+CREATE INDEX idx_status_logs_restored_url_id_checked_at ON status_logs_restored(url_id, checked_at);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS sla_alerts;
+
+ALTER TABLE urls
+    DROP COLUMN IF EXISTS sla_alert_thresholds,
+    DROP COLUMN IF EXISTS sla_window,
+    DROP COLUMN IF EXISTS sla_target;
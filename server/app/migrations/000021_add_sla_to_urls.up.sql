@@ -0,0 +1,13 @@
+ALTER TABLE urls
+    ADD COLUMN sla_target NUMERIC(6,3),
+    ADD COLUMN sla_window VARCHAR(20) NOT NULL DEFAULT 'rolling_30d',
+    ADD COLUMN sla_alert_thresholds BIGINT[] NOT NULL DEFAULT '{50,75,100}';
+
+-- Error budget thresholds already alerted on; a row is removed once consumption drops back
+-- below its threshold so the alert can fire again.
+CREATE TABLE sla_alerts (
+    url_id INT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    threshold BIGINT NOT NULL,
+    alerted_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (url_id, threshold)
+);
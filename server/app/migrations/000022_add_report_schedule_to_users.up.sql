@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN report_schedule VARCHAR(10) NOT NULL DEFAULT 'none';
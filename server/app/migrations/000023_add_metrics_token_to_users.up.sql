@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN metrics_token_hash VARCHAR(64) UNIQUE;
@@ -0,0 +1,35 @@
+ALTER TABLE status_log_rollups_daily
+    DROP COLUMN IF EXISTS timing_count,
+    DROP COLUMN IF EXISTS transfer_time_sum,
+    DROP COLUMN IF EXISTS ttfb_time_sum,
+    DROP COLUMN IF EXISTS tls_time_sum,
+    DROP COLUMN IF EXISTS connect_time_sum,
+    DROP COLUMN IF EXISTS dns_time_sum;
+
+ALTER TABLE status_log_rollups_hourly
+    DROP COLUMN IF EXISTS timing_count,
+    DROP COLUMN IF EXISTS transfer_time_sum,
+    DROP COLUMN IF EXISTS ttfb_time_sum,
+    DROP COLUMN IF EXISTS tls_time_sum,
+    DROP COLUMN IF EXISTS connect_time_sum,
+    DROP COLUMN IF EXISTS dns_time_sum;
+
+ALTER TABLE status_logs_restored
+    DROP COLUMN IF EXISTS response_size,
+    DROP COLUMN IF EXISTS protocol,
+    DROP COLUMN IF EXISTS remote_ip,
+    DROP COLUMN IF EXISTS transfer_time,
+    DROP COLUMN IF EXISTS ttfb_time,
+    DROP COLUMN IF EXISTS tls_time,
+    DROP COLUMN IF EXISTS connect_time,
+    DROP COLUMN IF EXISTS dns_time;
+
+ALTER TABLE status_logs
+    DROP COLUMN IF EXISTS response_size,
+    DROP COLUMN IF EXISTS protocol,
+    DROP COLUMN IF EXISTS remote_ip,
+    DROP COLUMN IF EXISTS transfer_time,
+    DROP COLUMN IF EXISTS ttfb_time,
+    DROP COLUMN IF EXISTS tls_time,
+    DROP COLUMN IF EXISTS connect_time,
+    DROP COLUMN IF EXISTS dns_time;
@@ -0,0 +1,39 @@
+-- Phase timings of a check in milliseconds. They stack up to the time the response body finished
+-- downloading, and are NULL for checks recorded before timings were collected.
+ALTER TABLE status_logs
+    ADD COLUMN dns_time INT,
+    ADD COLUMN connect_time INT,
+    ADD COLUMN tls_time INT,
+    ADD COLUMN ttfb_time INT,
+    ADD COLUMN transfer_time INT,
+    ADD COLUMN remote_ip VARCHAR(45),
+    ADD COLUMN protocol VARCHAR(16),
+    ADD COLUMN response_size BIGINT;
+
+ALTER TABLE status_logs_restored
+    ADD COLUMN dns_time INT,
+    ADD COLUMN connect_time INT,
+    ADD COLUMN tls_time INT,
+    ADD COLUMN ttfb_time INT,
+    ADD COLUMN transfer_time INT,
+    ADD COLUMN remote_ip VARCHAR(45),
+    ADD COLUMN protocol VARCHAR(16),
+    ADD COLUMN response_size BIGINT;
+
+-- Timing sums are averaged over timing_count rather than response_time_count, so hours that mix
+-- checks with and without timings still average correctly.
+ALTER TABLE status_log_rollups_hourly
+    ADD COLUMN dns_time_sum BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN connect_time_sum BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN tls_time_sum BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN ttfb_time_sum BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN transfer_time_sum BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN timing_count INT NOT NULL DEFAULT 0;
+
+ALTER TABLE status_log_rollups_daily
+    ADD COLUMN dns_time_sum BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN connect_time_sum BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN tls_time_sum BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN ttfb_time_sum BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN transfer_time_sum BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN timing_count INT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE status_page_subscribers DROP COLUMN IF EXISTS confirmation_sent_at;
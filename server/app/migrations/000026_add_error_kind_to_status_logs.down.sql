@@ -0,0 +1,14 @@
+ALTER TABLE status_log_rollups_daily
+    DROP COLUMN IF EXISTS timeout_checks,
+    DROP COLUMN IF EXISTS tls_checks,
+    DROP COLUMN IF EXISTS connect_checks,
+    DROP COLUMN IF EXISTS dns_checks;
+
+ALTER TABLE status_log_rollups_hourly
+    DROP COLUMN IF EXISTS timeout_checks,
+    DROP COLUMN IF EXISTS tls_checks,
+    DROP COLUMN IF EXISTS connect_checks,
+    DROP COLUMN IF EXISTS dns_checks;
+
+ALTER TABLE status_logs_restored DROP COLUMN IF EXISTS error_kind;
+ALTER TABLE status_logs DROP COLUMN IF EXISTS error_kind;
@@ -0,0 +1,32 @@
+-- Error kind of a failed check, NULL for successful checks. Checks that never got a response are
+-- recorded with status 0 and a dns, connect, tls, timeout or other kind.
+ALTER TABLE status_logs ADD COLUMN error_kind VARCHAR(16);
+ALTER TABLE status_logs_restored ADD COLUMN error_kind VARCHAR(16);
+
+UPDATE status_logs SET error_kind = CASE
+    WHEN status BETWEEN 300 AND 399 THEN 'redirect'
+    WHEN status BETWEEN 400 AND 499 THEN 'client_error'
+    WHEN status >= 500 THEN 'server_error'
+    ELSE 'other'
+END
+WHERE status NOT BETWEEN 200 AND 299;
+
+UPDATE status_logs_restored SET error_kind = CASE
+    WHEN status BETWEEN 300 AND 399 THEN 'redirect'
+    WHEN status BETWEEN 400 AND 499 THEN 'client_error'
+    WHEN status >= 500 THEN 'server_error'
+    ELSE 'other'
+END
+WHERE status NOT BETWEEN 200 AND 299;
+
+ALTER TABLE status_log_rollups_hourly
+    ADD COLUMN dns_checks INT NOT NULL DEFAULT 0,
+    ADD COLUMN connect_checks INT NOT NULL DEFAULT 0,
+    ADD COLUMN tls_checks INT NOT NULL DEFAULT 0,
+    ADD COLUMN timeout_checks INT NOT NULL DEFAULT 0;
+
+ALTER TABLE status_log_rollups_daily
+    ADD COLUMN dns_checks INT NOT NULL DEFAULT 0,
+    ADD COLUMN connect_checks INT NOT NULL DEFAULT 0,
+    ADD COLUMN tls_checks INT NOT NULL DEFAULT 0,
+    ADD COLUMN timeout_checks INT NOT NULL DEFAULT 0;